@@ -0,0 +1,69 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import (
+	"bytes"
+	"context"
+	"image"
+	_ "image/gif"  // register decoder for Image
+	_ "image/jpeg" // register decoder for Image
+	_ "image/png"  // register decoder for Image
+	"unsafe"
+)
+
+// AttachedPicture is an embedded picture like the cover art of MP3, FLAC or M4A files,
+// stored in a stream with the AV_DISPOSITION_ATTACHED_PIC flag.
+type AttachedPicture struct {
+	StreamIndex int               // Index of the stream carrying the picture.
+	CodecID     CodecID           // Codec of the picture, e.g. MJPEG or PNG.
+	CodecName   string            // Short name of the codec, e.g. mjpeg or png.
+	MIMEType    string            // MIME type of the picture, e.g. image/jpeg.
+	Width       int               // Width of the picture, 0 if the demuxer does not know it.
+	Height      int               // Height of the picture, 0 if the demuxer does not know it.
+	Size        int               // Size of Data in bytes.
+	Data        []byte            `json:"-"`          // Encoded picture as stored in the file.
+	Metadata    map[string]string `json:",omitempty"` // Stream metadata, e.g. comment "Cover (front)".
+}
+
+// Image decodes the picture. JPEG, PNG and GIF are supported out of the box,
+// further formats can be added by registering them with the image package.
+func (p *AttachedPicture) Image() (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(p.Data))
+	return img, err
+}
+
+// ExtractAttachedPictures returns the attached pictures (cover art) of a media file
+// in stream order. It returns nil without error if the file has none.
+func ExtractAttachedPictures(filename string) ([]AttachedPicture, error) {
+	in, err := openInput(context.Background(), filename, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer in.close()
+
+	var pictures []AttachedPicture
+	for i := range C.Get_stream_count(in.fc) {
+		s := C.Get_stream_by_index(in.fc, i)
+		if Disposition(s.disposition)&AV_DISPOSITION_ATTACHED_PIC == 0 || s.attached_pic.size <= 0 {
+			continue
+		}
+
+		pic := AttachedPicture{
+			StreamIndex: int(s.index),
+			CodecID:     CodecID(int(s.codecpar.codec_id)),
+			Width:       int(s.codecpar.width),
+			Height:      int(s.codecpar.height),
+			Size:        int(s.attached_pic.size),
+			Data:        C.GoBytes(unsafe.Pointer(s.attached_pic.data), s.attached_pic.size),
+			Metadata:    dictToMap(s.metadata),
+		}
+		pic.CodecName, pic.MIMEType = codecNameAndMIMEType(s.codecpar.codec_id)
+		pictures = append(pictures, pic)
+	}
+	return pictures, nil
+}
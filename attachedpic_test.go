@@ -0,0 +1,46 @@
+package mediafileinfo
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExtractAttachedPictures(t *testing.T) {
+	// FLAC header with a 2x2 PNG front cover in a PICTURE block
+	pictures, err := ExtractAttachedPictures("testdata/cover.flac")
+	if err != nil {
+		t.Fatalf("ExtractAttachedPictures returned error: %v", err)
+	}
+	if len(pictures) != 1 {
+		t.Fatalf("got %d pictures, want 1", len(pictures))
+	}
+
+	pic := pictures[0]
+	if pic.CodecName != "png" || pic.MIMEType != "image/png" {
+		t.Errorf("CodecName/MIMEType = %q/%q, want png/image/png", pic.CodecName, pic.MIMEType)
+	}
+	if pic.Width != 2 || pic.Height != 2 {
+		t.Errorf("size = %dx%d, want 2x2", pic.Width, pic.Height)
+	}
+	if pic.Size != len(pic.Data) || !bytes.HasPrefix(pic.Data, []byte("\x89PNG")) {
+		t.Errorf("Expected %d bytes of PNG data, got %d", pic.Size, len(pic.Data))
+	}
+
+	img, err := pic.Image()
+	if err != nil {
+		t.Fatalf("Image returned error: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 2 || b.Dy() != 2 {
+		t.Errorf("Image bounds = %v, want 2x2", b)
+	}
+}
+
+func TestExtractAttachedPictures_None(t *testing.T) {
+	pictures, err := ExtractAttachedPictures("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("ExtractAttachedPictures returned error: %v", err)
+	}
+	if len(pictures) != 0 {
+		t.Errorf("got %d pictures, want 0", len(pictures))
+	}
+}
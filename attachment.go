@@ -0,0 +1,129 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Attachment is a file embedded in a container, e.g. a TTF or OTF font of a Matroska
+// file which is needed to render its ASS subtitles. FFmpeg exposes attachments as
+// streams of type AVMEDIA_TYPE_ATTACHMENT.
+type Attachment struct {
+	StreamIndex int     // Index of the stream carrying the attachment.
+	Filename    string  // File name as stored in the container, may contain a path.
+	MIMEType    string  // MIME type as stored in the container, e.g. font/ttf.
+	CodecID     CodecID // Codec derived from the MIME type, e.g. TTF or OTF.
+	CodecName   string  // Short name of the codec, e.g. ttf or otf.
+	Size        int     // Size of Data in bytes.
+	Data        []byte  `json:"-"` // Content of the attached file.
+}
+
+// ListAttachments returns the attachments of a media file in stream order
+// including their content. It returns nil without error if there are none.
+func ListAttachments(filename string) ([]Attachment, error) {
+	in, err := openInput(context.Background(), filename, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer in.close()
+
+	var attachments []Attachment
+	for i := range C.Get_stream_count(in.fc) {
+		s := C.Get_stream_by_index(in.fc, i)
+		if AVMediaType(int(s.codecpar.codec_type)) != AVMEDIA_TYPE_ATTACHMENT {
+			continue
+		}
+
+		metadata := dictToMap(s.metadata)
+		a := Attachment{
+			StreamIndex: int(s.index),
+			Filename:    metadata["filename"],
+			MIMEType:    metadata["mimetype"],
+			CodecID:     CodecID(int(s.codecpar.codec_id)),
+			Size:        int(s.codecpar.extradata_size),
+		}
+		a.CodecName, _ = codecNameAndMIMEType(s.codecpar.codec_id)
+		if s.codecpar.extradata_size > 0 {
+			a.Data = C.GoBytes(unsafe.Pointer(s.codecpar.extradata), s.codecpar.extradata_size)
+		}
+		attachments = append(attachments, a)
+	}
+	return attachments, nil
+}
+
+// ExtractAttachments writes the attachments of a media file to dir, which has to
+// exist, and returns the paths of the written files. Only the base name of the
+// stored file name is used, so attachments cannot be written outside of dir.
+// A name used by an earlier attachment is prefixed with the stream index (and a
+// counter if that is taken as well). Existing files are never overwritten: such
+// a collision stops the extraction with an error matching fs.ErrExist, and the
+// paths written so far are returned.
+func ExtractAttachments(filename, dir string) ([]string, error) {
+	attachments, err := ListAttachments(filename)
+	if err != nil {
+		return nil, err
+	}
+	return writeAttachments(attachments, filename, dir)
+}
+
+// writeAttachments is ExtractAttachments for already listed attachments.
+func writeAttachments(attachments []Attachment, filename, dir string) ([]string, error) {
+	var paths []string
+	used := make(map[string]bool)
+	for _, a := range attachments {
+		name := a.SafeFilename()
+		prefix := strconv.Itoa(a.StreamIndex) + "_"
+		for n := 1; used[strings.ToLower(name)]; n++ {
+			if n == 1 {
+				name = prefix + a.SafeFilename()
+			} else {
+				name = prefix + strconv.Itoa(n) + "_" + a.SafeFilename()
+			}
+		}
+		used[strings.ToLower(name)] = true
+
+		p := filepath.Join(dir, name)
+		if err := writeNewFile(p, a.Data); err != nil {
+			return paths, fmt.Errorf("could not write attachment %d of %s: %w", a.StreamIndex, filename, err)
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+// writeNewFile writes data to the file p, which must not exist yet.
+func writeNewFile(p string, data []byte) error {
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// SafeFilename returns the base name of the stored file name with any directory
+// removed (both '/' and '\' are treated as separators), or "attachment_<index>"
+// if nothing usable remains.
+func (a *Attachment) SafeFilename() string {
+	name := path.Base(strings.ReplaceAll(a.Filename, `\`, "/"))
+	switch name {
+	case ".", "..", "/", "":
+		return "attachment_" + strconv.Itoa(a.StreamIndex)
+	}
+	return name
+}
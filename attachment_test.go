@@ -0,0 +1,135 @@
+package mediafileinfo
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListAttachments(t *testing.T) {
+	// Matroska file with a TTF and an OTF attachment, the latter with a path in its name
+	attachments, err := ListAttachments("testdata/attachments.mkv")
+	if err != nil {
+		t.Fatalf("ListAttachments returned error: %v", err)
+	}
+	if len(attachments) != 2 {
+		t.Fatalf("got %d attachments, want 2", len(attachments))
+	}
+
+	tests := []struct {
+		filename, mimeType, codecName, data string
+	}{
+		{"Test Font.ttf", "font/ttf", "ttf", "TTF-DATA-0123456789"},
+		{"../fonts/Evil.otf", "font/otf", "otf", "OTF-DATA"},
+	}
+	for i, tt := range tests {
+		a := attachments[i]
+		if a.Filename != tt.filename || a.MIMEType != tt.mimeType {
+			t.Errorf("attachment %d: Filename/MIMEType = %q/%q, want %q/%q", i, a.Filename, a.MIMEType, tt.filename, tt.mimeType)
+		}
+		if a.CodecName != tt.codecName {
+			t.Errorf("attachment %d: CodecName = %q, want %q", i, a.CodecName, tt.codecName)
+		}
+		if string(a.Data) != tt.data || a.Size != len(tt.data) {
+			t.Errorf("attachment %d: Data = %q (Size %d), want %q", i, a.Data, a.Size, tt.data)
+		}
+	}
+}
+
+func TestAttachment_SafeFilename(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"font.ttf", "font.ttf"},
+		{"../fonts/Evil.otf", "Evil.otf"},
+		{`..\..\windows\evil.ttf`, "evil.ttf"},
+		{"/etc/", "etc"},
+		{"..", "attachment_2"},
+		{"", "attachment_2"},
+	}
+
+	for _, tt := range tests {
+		a := Attachment{StreamIndex: 2, Filename: tt.in}
+		if got := a.SafeFilename(); got != tt.want {
+			t.Errorf("SafeFilename(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExtractAttachments(t *testing.T) {
+	dir := t.TempDir()
+	paths, err := ExtractAttachments("testdata/attachments.mkv", dir)
+	if err != nil {
+		t.Fatalf("ExtractAttachments returned error: %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "Test Font.ttf"), filepath.Join(dir, "Evil.otf")}
+	if len(paths) != len(want) {
+		t.Fatalf("got %d paths, want %d", len(paths), len(want))
+	}
+	for i, p := range paths {
+		if p != want[i] {
+			t.Errorf("path %d = %q, want %q", i, p, want[i])
+		}
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("Expected %s to exist: %v", p, err)
+		}
+	}
+}
+
+func TestWriteAttachments_Collisions(t *testing.T) {
+	dir := t.TempDir()
+	attachments := []Attachment{
+		{StreamIndex: 2, Filename: "font.ttf", Data: []byte("first")},
+		{StreamIndex: 3, Filename: "fonts/FONT.ttf", Data: []byte("second")},
+		{StreamIndex: 4, Filename: "3_font.ttf", Data: []byte("third")},
+		{StreamIndex: 3, Filename: "font.ttf", Data: []byte("fourth")},
+	}
+	paths, err := writeAttachments(attachments, "test.mkv", dir)
+	if err != nil {
+		t.Fatalf("writeAttachments returned error: %v", err)
+	}
+
+	want := []struct{ name, data string }{
+		{"font.ttf", "first"},
+		{"3_FONT.ttf", "second"},
+		{"4_3_font.ttf", "third"},
+		{"3_2_font.ttf", "fourth"},
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("got %d paths, want %d", len(paths), len(want))
+	}
+	for i, w := range want {
+		if p := filepath.Join(dir, w.name); paths[i] != p {
+			t.Errorf("path %d = %q, want %q", i, paths[i], p)
+		}
+		if data, err := os.ReadFile(paths[i]); err != nil || string(data) != w.data {
+			t.Errorf("%s = %q (%v), want %q", paths[i], data, err, w.data)
+		}
+	}
+}
+
+func TestWriteAttachments_ExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "font.ttf")
+	if err := os.WriteFile(existing, []byte("keep me"), 0o644); err != nil {
+		t.Fatalf("could not write test file: %v", err)
+	}
+
+	attachments := []Attachment{
+		{StreamIndex: 1, Filename: "other.ttf", Data: []byte("other")},
+		{StreamIndex: 2, Filename: "font.ttf", Data: []byte("attachment")},
+	}
+	paths, err := writeAttachments(attachments, "test.mkv", dir)
+	if !errors.Is(err, fs.ErrExist) {
+		t.Errorf("writeAttachments = %v, want fs.ErrExist", err)
+	}
+	if len(paths) != 1 || paths[0] != filepath.Join(dir, "other.ttf") {
+		t.Errorf("paths = %v, want the file written before the collision", paths)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "keep me" {
+		t.Errorf("existing file was overwritten with %q", data)
+	}
+}
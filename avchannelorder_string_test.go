@@ -0,0 +1,26 @@
+package mediafileinfo
+
+import (
+	"testing"
+)
+
+func TestAVChannelOrder_String(t *testing.T) {
+	tests := []struct {
+		val  AVChannelOrder
+		want string
+	}{
+		{AV_CHANNEL_ORDER_UNSPEC, "UNSPEC"},
+		{AV_CHANNEL_ORDER_NATIVE, "NATIVE"},
+		{AV_CHANNEL_ORDER_CUSTOM, "CUSTOM"},
+		{AV_CHANNEL_ORDER_AMBISONIC, "AMBISONIC"},
+		{AVChannelOrder(99), "AVChannelOrder(99)"},
+		{AVChannelOrder(-1), "AVChannelOrder(-1)"},
+	}
+
+	for _, tt := range tests {
+		got := tt.val.String()
+		if got != tt.want {
+			t.Errorf("AVChannelOrder(%d).String() = %q, want %q", tt.val, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// BitrateProfile is the bitrate of one stream over time, derived from packet sizes
+// and timestamps without decoding. Bitrates are in bits per second.
+type BitrateProfile struct {
+	StreamIndex     int             // Index of the analyzed stream.
+	CodecType       AVMediaType     // Type of the stream.
+	CodecTypeText   string          // Type of the stream as text
+	Window          time.Duration   // Length of the sampling windows.
+	Samples         []BitrateSample // Bitrate of each window from the first packet on, empty windows included.
+	Bytes           int64           // Total size of all counted packets.
+	Duration        time.Duration   // Time from the first packet to the end of the last one.
+	Average         float64         // Average bitrate over Duration.
+	Peak            float64         // Highest bitrate of all full windows.
+	PeakTime        time.Duration   // Start of the window with the highest bitrate.
+	Min             float64         // Lowest bitrate of all full windows.
+	StdDev          float64         // Standard deviation of the bitrates of all full windows.
+	TimestampErrors int             `json:",omitempty"` // Packets left out because their timestamp lies far beyond the stream duration.
+}
+
+// BitrateSample is the bitrate of one window.
+type BitrateSample struct {
+	Start   time.Duration // Start of the window.
+	Bytes   int64         // Size of the packets in the window.
+	BitRate float64       // Bitrate of the window; a partial last window is related to the full window length.
+}
+
+// maxBitrateSamples bounds the number of windows of a profile.
+const maxBitrateSamples = 1 << 20
+
+// AnalyzeBitrate reads all packets of filename and returns the bitrate profile of
+// every stream sampled in windows of the given length, e.g. 1 second. Packets are
+// assigned to windows by their decoding timestamp. Attached pictures and streams
+// without packets are left out. A window <= 0, or one so short that the file
+// would need more than 1<<20 windows, is an error.
+func AnalyzeBitrate(filename string, window time.Duration) ([]BitrateProfile, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("invalid bitrate window %v", window)
+	}
+
+	ctx := context.Background()
+	in, err := openInput(ctx, filename, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer in.close()
+
+	fileDuration := ticksToDuration(in.fc.duration, avTimeBaseQ)
+	if fileDuration/window >= maxBitrateSamples {
+		return nil, fmt.Errorf("bitrate window %v too short for %s of %v", window, filename, fileDuration)
+	}
+
+	builders := make(map[int]*bitrateBuilder)
+	num := int(C.Get_stream_count(in.fc))
+	for i := range num {
+		s := C.Get_stream_by_index(in.fc, C.int(i))
+		if Disposition(s.disposition).IsAttachedPic() {
+			continue
+		}
+		duration := ticksToDuration(s.duration, s.time_base)
+		if duration <= 0 {
+			duration = fileDuration
+		}
+		builders[i] = &bitrateBuilder{window: window, limit: bitrateLimit(duration, window)}
+	}
+
+	err = scanPackets(ctx, in, filename, func(p Packet) error {
+		if b := builders[p.StreamIndex]; b != nil {
+			b.add(p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []BitrateProfile
+	for i := range num {
+		b := builders[i]
+		if b == nil || !b.started {
+			continue
+		}
+		ctp := AVMediaType(int(C.Get_stream_by_index(in.fc, C.int(i)).codecpar.codec_type))
+		p := b.profile()
+		p.StreamIndex, p.CodecType, p.CodecTypeText = i, ctp, ctp.String()
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
+
+// WriteCSV writes the samples as CSV with the columns stream, codec_type,
+// start_seconds, bytes and bitrate, preceded by a header line.
+func (p *BitrateProfile) WriteCSV(w io.Writer) error {
+	return WriteBitrateCSV(w, []BitrateProfile{*p})
+}
+
+// WriteBitrateCSV writes the samples of all profiles to w in the format of
+// BitrateProfile.WriteCSV, e.g. for plotting the streams together.
+func WriteBitrateCSV(w io.Writer, profiles []BitrateProfile) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"stream", "codec_type", "start_seconds", "bytes", "bitrate"}); err != nil {
+		return err
+	}
+	for _, p := range profiles {
+		for _, s := range p.Samples {
+			err := cw.Write([]string{
+				strconv.Itoa(p.StreamIndex),
+				p.CodecTypeText,
+				strconv.FormatFloat(s.Start.Seconds(), 'f', 3, 64),
+				strconv.FormatInt(s.Bytes, 10),
+				strconv.FormatFloat(s.BitRate, 'f', 0, 64),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// bitrateLimit returns the number of windows a stream of the given duration may
+// fill: twice its duration leaves room for inaccurate headers. Without a known
+// duration only maxBitrateSamples applies.
+func bitrateLimit(duration, window time.Duration) int {
+	if duration <= 0 {
+		return maxBitrateSamples
+	}
+	return int(min(2*duration/window+1, maxBitrateSamples))
+}
+
+// bitrateBuilder sums up the packet sizes of one stream per window.
+type bitrateBuilder struct {
+	window  time.Duration
+	limit   int // maximum number of windows, see bitrateLimit
+	started bool
+	start   time.Duration // time of the first packet
+	last    time.Duration // time of the latest packet, for packets without timestamp
+	end     time.Duration // end of the last packet
+	bytes   []int64       // bytes per window
+	badTS   int           // packets beyond limit
+}
+
+// add accounts the packet to the window of its DTS, or of its PTS if the DTS is unknown.
+func (b *bitrateBuilder) add(p Packet) {
+	t := b.last
+	switch {
+	case p.DTS != AV_NOPTS_VALUE:
+		t = p.DTSTime
+	case p.PTS != AV_NOPTS_VALUE:
+		t = p.PTSTime
+	}
+	if !b.started {
+		b.started, b.start, b.end = true, t, t
+	}
+
+	i := max(t-b.start, 0) / b.window
+	if i >= time.Duration(b.limit) {
+		b.badTS++
+		return
+	}
+	b.last = t
+	b.end = max(b.end, t+p.DurationTime)
+
+	for len(b.bytes) <= int(i) {
+		b.bytes = append(b.bytes, 0)
+	}
+	b.bytes[i] += int64(p.Size)
+}
+
+// profile computes the samples and statistics. A partial last window would
+// distort Peak, Min and StdDev, so it only counts when it is the only window.
+func (b *bitrateBuilder) profile() BitrateProfile {
+	p := BitrateProfile{Window: b.window, Duration: b.end - b.start, TimestampErrors: b.badTS}
+	if len(b.bytes) == 0 {
+		return p
+	}
+
+	full := len(b.bytes)
+	if last := b.start + time.Duration(full-1)*b.window; full > 1 && b.end-last < b.window {
+		full--
+	}
+
+	var sum, sumSq float64
+	p.Min = math.Inf(1)
+	for i, n := range b.bytes {
+		start := b.start + time.Duration(i)*b.window
+		rate := float64(n*8) / b.window.Seconds()
+		p.Samples = append(p.Samples, BitrateSample{Start: start, Bytes: n, BitRate: rate})
+		p.Bytes += n
+		if i >= full {
+			continue
+		}
+
+		if rate > p.Peak {
+			p.Peak, p.PeakTime = rate, start
+		}
+		p.Min = min(p.Min, rate)
+		sum += rate
+		sumSq += rate * rate
+	}
+
+	n := float64(full)
+	mean := sum / n
+	p.StdDev = math.Sqrt(max(sumSq/n-mean*mean, 0))
+	if p.Duration > 0 {
+		p.Average = float64(p.Bytes*8) / p.Duration.Seconds()
+	} else {
+		p.Average = mean
+	}
+	return p
+}
@@ -0,0 +1,113 @@
+package mediafileinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBitrateBuilder(t *testing.T) {
+	// 1s windows: 1000 bytes in the first, nothing in the second, 500 bytes in the last half second
+	b := &bitrateBuilder{window: time.Second, limit: bitrateLimit(2500*time.Millisecond, time.Second)}
+	pkt := func(ms int64, size int) Packet {
+		d := time.Duration(ms) * time.Millisecond
+		return Packet{PTS: ms, PTSTime: d, DTS: ms, DTSTime: d, DurationTime: 500 * time.Millisecond, Size: size}
+	}
+	b.add(pkt(0, 600))
+	b.add(pkt(500, 400))
+	b.add(pkt(2000, 500))
+
+	p := b.profile()
+	if len(p.Samples) != 3 {
+		t.Fatalf("got %d samples, want 3", len(p.Samples))
+	}
+	// the partial last window is related to the full window length
+	want := []float64{8000, 0, 4000}
+	for i, s := range p.Samples {
+		if s.BitRate != want[i] {
+			t.Errorf("sample %d: BitRate = %v, want %v", i, s.BitRate, want[i])
+		}
+	}
+	if p.Bytes != 1500 || p.Duration != 2500*time.Millisecond {
+		t.Errorf("Bytes/Duration = %d/%v, want 1500/2.5s", p.Bytes, p.Duration)
+	}
+	if p.Average != 4800 {
+		t.Errorf("Average = %v, want 4800", p.Average)
+	}
+	// statistics over the full windows 8000 and 0 only
+	if p.Peak != 8000 || p.PeakTime != 0 || p.Min != 0 {
+		t.Errorf("Peak/PeakTime/Min = %v/%v/%v, want 8000/0s/0", p.Peak, p.PeakTime, p.Min)
+	}
+	if p.StdDev != 4000 {
+		t.Errorf("StdDev = %v, want 4000", p.StdDev)
+	}
+
+	// a bogus DTS far beyond the duration is counted instead of growing the profile
+	b.add(pkt(int64(time.Hour/time.Millisecond), 100))
+	p = b.profile()
+	if len(p.Samples) != 3 || p.TimestampErrors != 1 || p.Bytes != 1500 {
+		t.Errorf("Samples/TimestampErrors/Bytes = %d/%d/%d, want 3/1/1500", len(p.Samples), p.TimestampErrors, p.Bytes)
+	}
+}
+
+func TestBitrateLimit(t *testing.T) {
+	tests := []struct {
+		duration, window time.Duration
+		want             int
+	}{
+		{10 * time.Second, time.Second, 21},
+		{0, time.Second, maxBitrateSamples},
+		{time.Hour, time.Nanosecond, maxBitrateSamples},
+	}
+	for _, tt := range tests {
+		if got := bitrateLimit(tt.duration, tt.window); got != tt.want {
+			t.Errorf("bitrateLimit(%v, %v) = %d, want %d", tt.duration, tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeBitrate(t *testing.T) {
+	profiles, err := AnalyzeBitrate("testdata/sample.avi", 200*time.Millisecond)
+	if err != nil {
+		t.Fatalf("AnalyzeBitrate returned error: %v", err)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("got %d profiles, want 2", len(profiles))
+	}
+
+	audio := profiles[1]
+	if audio.CodecType != AVMEDIA_TYPE_AUDIO || len(audio.Samples) == 0 {
+		t.Fatalf("profile 1: CodecType = %v with %d samples, want audio samples", audio.CodecType, len(audio.Samples))
+	}
+	// 32 kHz stereo float PCM has a constant bitrate of 2048 kbit/s
+	if audio.Average < 2000000 || audio.Average > 2100000 {
+		t.Errorf("audio: Average = %v, want about 2048000", audio.Average)
+	}
+	if audio.Peak < audio.Average || audio.Min > audio.Average {
+		t.Errorf("audio: Min/Average/Peak = %v/%v/%v out of order", audio.Min, audio.Average, audio.Peak)
+	}
+
+	for _, w := range []time.Duration{0, -time.Second, time.Nanosecond} {
+		if _, err := AnalyzeBitrate("testdata/sample.avi", w); err == nil {
+			t.Errorf("AnalyzeBitrate with window %v: Expected error", w)
+		}
+	}
+
+	if _, err := json.Marshal(profiles); err != nil {
+		t.Errorf("json.Marshal returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBitrateCSV(&buf, profiles); err != nil {
+		t.Fatalf("WriteBitrateCSV returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "stream,codec_type,start_seconds,bytes,bitrate" {
+		t.Errorf("CSV header = %q", lines[0])
+	}
+	if want := 1 + len(profiles[0].Samples) + len(audio.Samples); len(lines) != want {
+		t.Errorf("got %d CSV lines, want %d", len(lines), want)
+	}
+}
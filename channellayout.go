@@ -0,0 +1,56 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+#include <libavutil/channel_layout.h>
+*/
+import "C"
+
+// newAVChannelLayout maps a C AVChannelLayout. Returns nil if the layout has no channels.
+func newAVChannelLayout(layout *C.AVChannelLayout) *AVChannelLayout {
+	if layout.nb_channels <= 0 {
+		return nil
+	}
+
+	var buf [128]C.char
+	order := AVChannelOrder(layout.order)
+	cl := &AVChannelLayout{
+		Order:     order,
+		OrderText: order.String(),
+		Channels:  int(layout.nb_channels),
+		Mask:      uint64(C.Get_channel_layout_mask(layout)),
+	}
+
+	cl.Description = describeChannelLayout(layout, buf[:])
+
+	if order != AV_CHANNEL_ORDER_UNSPEC {
+		for i := range C.uint(layout.nb_channels) {
+			ch := C.av_channel_layout_channel_from_index(layout, i)
+			if C.av_channel_name(&buf[0], C.size_t(len(buf)), ch) < 0 {
+				cl.ChannelNames = append(cl.ChannelNames, "")
+				continue
+			}
+			cl.ChannelNames = append(cl.ChannelNames, C.GoString(&buf[0]))
+		}
+	}
+	return cl
+}
+
+// describeChannelLayout returns the av_channel_layout_describe string, retrying
+// with a larger buffer when buf is too small. Returns "" on failure.
+func describeChannelLayout(layout *C.AVChannelLayout, buf []C.char) string {
+	ret := C.av_channel_layout_describe(layout, &buf[0], C.size_t(len(buf)))
+	if ret < 0 {
+		return ""
+	}
+	// ret is the size needed including the terminating NUL
+	if int(ret) > len(buf) {
+		buf = make([]C.char, int(ret))
+		if C.av_channel_layout_describe(layout, &buf[0], C.size_t(len(buf))) < 0 {
+			return ""
+		}
+	}
+	return C.GoString(&buf[0])
+}
@@ -0,0 +1,74 @@
+package mediafileinfo
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGetMediaInfo_ChannelLayout(t *testing.T) {
+	info, err := GetMediaInfo("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+
+	for _, s := range info.Streams {
+		par := s.CodecParameters
+		if par.CodecType != AVMEDIA_TYPE_AUDIO {
+			if par.ChannelLayout != nil {
+				t.Errorf("stream %d: Expected no ChannelLayout for %v", s.Index, par.CodecType)
+			}
+			continue
+		}
+
+		cl := par.ChannelLayout
+		if cl == nil {
+			t.Fatalf("stream %d: Expected a ChannelLayout", s.Index)
+		}
+		if cl.Order != AV_CHANNEL_ORDER_NATIVE {
+			t.Errorf("Order = %v, want NATIVE", cl.Order)
+		}
+		if cl.Channels != 2 {
+			t.Errorf("Channels = %d, want 2", cl.Channels)
+		}
+		if cl.Mask != 0x3 {
+			t.Errorf("Mask = %#x, want 0x3", cl.Mask)
+		}
+		if !slices.Equal(cl.ChannelNames, []string{"FL", "FR"}) {
+			t.Errorf("ChannelNames = %v, want [FL FR]", cl.ChannelNames)
+		}
+		if cl.Description != "stereo" {
+			t.Errorf("Description = %q, want %q", cl.Description, "stereo")
+		}
+	}
+}
+
+func TestGetMediaInfo_ChannelLayoutLongDescription(t *testing.T) {
+	// WAVE_FORMAT_EXTENSIBLE with all 32 dwChannelMask bits set; the description
+	// is longer than the initial describe buffer
+	info, err := GetMediaInfo("testdata/channels32.wav")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+	if len(info.Streams) != 1 {
+		t.Fatalf("got %d streams, want 1", len(info.Streams))
+	}
+
+	cl := info.Streams[0].CodecParameters.ChannelLayout
+	if cl == nil {
+		t.Fatal("Expected a ChannelLayout")
+	}
+	if cl.Channels != 32 || cl.Mask != 0xFFFFFFFF {
+		t.Errorf("Channels/Mask = %d/%#x, want 32/0xffffffff", cl.Channels, cl.Mask)
+	}
+	if len(cl.ChannelNames) != 32 {
+		t.Errorf("got %d ChannelNames, want 32", len(cl.ChannelNames))
+	}
+	desc := cl.Description
+	if len(desc) < 128 || !strings.HasPrefix(desc, "32 channels (FL+FR+") || !strings.HasSuffix(desc, "+WL)") {
+		t.Errorf("Description = %q, want the full untruncated channel list", desc)
+	}
+	if n := strings.Count(desc, "+"); n != 31 {
+		t.Errorf("Description has %d separators, want 31", n)
+	}
+}
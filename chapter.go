@@ -0,0 +1,42 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import "time"
+
+// AVChapter represents a chapter of a media file (e.g. in m4b audiobooks or Matroska films), mirroring FFmpeg's AVChapter.
+// See: https://ffmpeg.org/doxygen/trunk/structAVChapter.html
+type AVChapter struct {
+	ID        int64             // Unique ID to identify the chapter.
+	TimeBase  AVRational        // Time base in which Start and End are specified.
+	Start     int64             // Chapter start in TimeBase units.
+	End       int64             // Chapter end in TimeBase units.
+	StartTime time.Duration     // Chapter start as real time.
+	EndTime   time.Duration     // Chapter end as real time.
+	Title     string            `json:",omitempty"` // Title tag of the chapter.
+	Metadata  map[string]string `json:",omitempty"` // All metadata tags of the chapter.
+}
+
+// newAVChapters maps the chapters of an opened C AVFormatContext.
+func newAVChapters(ctx *C.AVFormatContext) []AVChapter {
+	var chapters []AVChapter
+	for i := range C.int(ctx.nb_chapters) {
+		c := C.Get_chapter_by_index(ctx, i)
+		metadata := dictToMap(c.metadata)
+		chapters = append(chapters, AVChapter{
+			ID:        int64(c.id),
+			TimeBase:  AVRational{Num: int(c.time_base.num), Den: int(c.time_base.den)},
+			Start:     int64(c.start),
+			End:       int64(c.end),
+			StartTime: ticksToDuration(c.start, c.time_base),
+			EndTime:   ticksToDuration(c.end, c.time_base),
+			Title:     metadata["title"],
+			Metadata:  metadata,
+		})
+	}
+	return chapters
+}
@@ -0,0 +1,42 @@
+package mediafileinfo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetMediaInfo_Chapters(t *testing.T) {
+	info, err := GetMediaInfo("testdata/chapters.ffmeta")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+	if len(info.Chapters) != 2 {
+		t.Fatalf("got %d chapters, want 2", len(info.Chapters))
+	}
+
+	tests := []struct {
+		title      string
+		start, end int64
+		startTime  time.Duration
+		endTime    time.Duration
+	}{
+		{"Chapter 1", 0, 60000, 0, time.Minute},
+		{"Chapter 2", 60000, 125500, time.Minute, 2*time.Minute + 5500*time.Millisecond},
+	}
+
+	for i, tt := range tests {
+		c := info.Chapters[i]
+		if c.Title != tt.title {
+			t.Errorf("chapter %d: Title = %q, want %q", i, c.Title, tt.title)
+		}
+		if c.TimeBase != (AVRational{Num: 1, Den: 1000}) {
+			t.Errorf("chapter %d: TimeBase = %v, want 1:1000", i, c.TimeBase)
+		}
+		if c.Start != tt.start || c.End != tt.end {
+			t.Errorf("chapter %d: Start/End = %d/%d, want %d/%d", i, c.Start, c.End, tt.start, tt.end)
+		}
+		if c.StartTime != tt.startTime || c.EndTime != tt.endTime {
+			t.Errorf("chapter %d: StartTime/EndTime = %v/%v, want %v/%v", i, c.StartTime, c.EndTime, tt.startTime, tt.endTime)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+#include <libavcodec/codec_desc.h>
+*/
+import "C"
+import (
+	"fmt"
+	"strconv"
+)
+
+// CodecProperties holds the properties of a codec from FFmpeg's AVCodecDescriptor.
+// See: https://ffmpeg.org/doxygen/trunk/structAVCodecDescriptor.html
+type CodecProperties struct {
+	IntraOnly bool `json:",omitempty"` // Codec uses only intra compression.
+	Lossy     bool `json:",omitempty"` // Codec supports lossy compression.
+	Lossless  bool `json:",omitempty"` // Codec supports lossless compression.
+	Reorder   bool `json:",omitempty"` // Codec supports frame reordering (B-frames), packets are not in presentation order.
+	Fields    bool `json:",omitempty"` // Video codec supports separate coding of fields in interlaced frames.
+	BitmapSub bool `json:",omitempty"` // Subtitle codec is bitmap based.
+	TextSub   bool `json:",omitempty"` // Subtitle codec is text based.
+}
+
+// ProfileLevel returns profile and level in the usual notation, e.g. "High@4.1" or
+// "Main 10@5.1". Returns only the part which is known or "" if neither is.
+func (p *AVCodecParameters) ProfileLevel() string {
+	switch {
+	case p.ProfileText != "" && p.LevelText != "":
+		return p.ProfileText + "@" + p.LevelText
+	case p.ProfileText != "":
+		return p.ProfileText
+	}
+	return p.LevelText
+}
+
+// setCodecDescriptor fills the codec names, the codec properties and the
+// profile and level texts from the codec descriptor.
+func (p *AVCodecParameters) setCodecDescriptor(id C.enum_AVCodecID) {
+	if name := C.avcodec_profile_name(id, C.int(p.Profile)); name != nil {
+		p.ProfileText = C.GoString(name)
+	}
+	p.LevelText = levelText(id, p.Level)
+
+	desc := C.avcodec_descriptor_get(id)
+	if desc == nil {
+		return
+	}
+	p.CodecName = C.GoString(desc.name)
+	if desc.long_name != nil {
+		p.CodecLongName = C.GoString(desc.long_name)
+	}
+	props := desc.props
+	p.CodecProperties = CodecProperties{
+		IntraOnly: props&C.AV_CODEC_PROP_INTRA_ONLY != 0,
+		Lossy:     props&C.AV_CODEC_PROP_LOSSY != 0,
+		Lossless:  props&C.AV_CODEC_PROP_LOSSLESS != 0,
+		Reorder:   props&C.AV_CODEC_PROP_REORDER != 0,
+		Fields:    props&C.AV_CODEC_PROP_FIELDS != 0,
+		BitmapSub: props&C.AV_CODEC_PROP_BITMAP_SUB != 0,
+		TextSub:   props&C.AV_CODEC_PROP_TEXT_SUB != 0,
+	}
+}
+
+// levelText formats the codec specific level the way the codec's specification
+// writes it, e.g. 41 for H.264 and 123 for HEVC both become "4.1".
+// Returns "" for an unknown level.
+func levelText(id C.enum_AVCodecID, level int) string {
+	if level == C.AV_LEVEL_UNKNOWN || level < 0 {
+		return ""
+	}
+	switch id {
+	case C.AV_CODEC_ID_H264:
+		if level == 9 {
+			return "1b"
+		}
+		return fmt.Sprintf("%d.%d", level/10, level%10)
+	case C.AV_CODEC_ID_HEVC:
+		// general_level_idc is 30 times the level number
+		return fmt.Sprintf("%d.%d", level/30, level%30/3)
+	case C.AV_CODEC_ID_VVC:
+		// general_level_idc is major * 16 + minor * 3
+		return fmt.Sprintf("%d.%d", level/16, level%16/3)
+	case C.AV_CODEC_ID_AV1:
+		// seq_level_idx 0 is level 2.0, four minor levels per major level
+		return fmt.Sprintf("%d.%d", 2+level/4, level%4)
+	}
+	return strconv.Itoa(level)
+}
+
+// codecNameAndMIMEType returns the short name and the preferred MIME type of a
+// codec from its descriptor; both are empty if FFmpeg does not know them.
+func codecNameAndMIMEType(id C.enum_AVCodecID) (name, mimeType string) {
+	desc := C.avcodec_descriptor_get(id)
+	if desc == nil {
+		return "", ""
+	}
+	name = C.GoString(desc.name)
+	if desc.mime_types != nil {
+		mimeType = C.GoString(*desc.mime_types)
+	}
+	return name, mimeType
+}
@@ -0,0 +1,43 @@
+package mediafileinfo
+
+import "testing"
+
+func TestAVCodecParameters_ProfileLevel(t *testing.T) {
+	tests := []struct {
+		profile, level, want string
+	}{
+		{"High", "4.1", "High@4.1"},
+		{"Main 10", "", "Main 10"},
+		{"", "3", "3"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		p := AVCodecParameters{ProfileText: tt.profile, LevelText: tt.level}
+		if got := p.ProfileLevel(); got != tt.want {
+			t.Errorf("ProfileLevel() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestGetMediaInfo_CodecDescriptor(t *testing.T) {
+	info, err := GetMediaInfo("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+
+	for _, s := range info.Streams {
+		par := s.CodecParameters
+		if par.CodecName == "" || par.CodecLongName == "" {
+			t.Errorf("stream %d: Expected codec names, got %q / %q", s.Index, par.CodecName, par.CodecLongName)
+		}
+		if par.CodecType == AVMEDIA_TYPE_VIDEO {
+			if par.CodecName != "ffvhuff" {
+				t.Errorf("CodecName = %q, want ffvhuff", par.CodecName)
+			}
+			if !par.CodecProperties.IntraOnly || !par.CodecProperties.Lossless {
+				t.Errorf("CodecProperties = %+v, want intra only and lossless", par.CodecProperties)
+			}
+		}
+	}
+}
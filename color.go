@@ -0,0 +1,136 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+#include <libavutil/pixdesc.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"fmt"
+	"strconv"
+	"unsafe"
+)
+
+// String returns the FFmpeg name of the color range: unknown, tv or pc.
+func (r AVColorRange) String() string {
+	return colorName(C.av_color_range_name(C.enum_AVColorRange(r)), "AVColorRange", int(r))
+}
+
+// MarshalText encodes the color range by its FFmpeg name, or as a number if FFmpeg has none.
+func (r AVColorRange) MarshalText() ([]byte, error) {
+	return colorText(C.av_color_range_name(C.enum_AVColorRange(r)), int(r)), nil
+}
+
+// UnmarshalText decodes an FFmpeg color range name, e.g. tv or pc, or a numeric value.
+func (r *AVColorRange) UnmarshalText(text []byte) error {
+	v, err := parseColorName(text, "AVColorRange", func(name *C.char) C.int { return C.av_color_range_from_name(name) })
+	*r = AVColorRange(v)
+	return err
+}
+
+// String returns the FFmpeg name of the color primaries, e.g. bt709 or bt2020.
+func (p AVColorPrimaries) String() string {
+	return colorName(C.av_color_primaries_name(C.enum_AVColorPrimaries(p)), "AVColorPrimaries", int(p))
+}
+
+// MarshalText encodes the color primaries by their FFmpeg name, or as a number if FFmpeg has none.
+func (p AVColorPrimaries) MarshalText() ([]byte, error) {
+	return colorText(C.av_color_primaries_name(C.enum_AVColorPrimaries(p)), int(p)), nil
+}
+
+// UnmarshalText decodes an FFmpeg color primaries name, e.g. bt2020, or a numeric value.
+func (p *AVColorPrimaries) UnmarshalText(text []byte) error {
+	v, err := parseColorName(text, "AVColorPrimaries", func(name *C.char) C.int { return C.av_color_primaries_from_name(name) })
+	*p = AVColorPrimaries(v)
+	return err
+}
+
+// String returns the FFmpeg name of the transfer characteristic, e.g. bt709, smpte2084 or arib-std-b67.
+func (t AVColorTransferCharacteristic) String() string {
+	return colorName(C.av_color_transfer_name(C.enum_AVColorTransferCharacteristic(t)), "AVColorTransferCharacteristic", int(t))
+}
+
+// MarshalText encodes the transfer characteristic by its FFmpeg name, or as a number if FFmpeg has none.
+func (t AVColorTransferCharacteristic) MarshalText() ([]byte, error) {
+	return colorText(C.av_color_transfer_name(C.enum_AVColorTransferCharacteristic(t)), int(t)), nil
+}
+
+// UnmarshalText decodes an FFmpeg transfer characteristic name, e.g. smpte2084, or a numeric value.
+func (t *AVColorTransferCharacteristic) UnmarshalText(text []byte) error {
+	v, err := parseColorName(text, "AVColorTransferCharacteristic", func(name *C.char) C.int { return C.av_color_transfer_from_name(name) })
+	*t = AVColorTransferCharacteristic(v)
+	return err
+}
+
+// String returns the FFmpeg name of the color space, e.g. bt709 or bt2020nc.
+func (s AVColorSpace) String() string {
+	return colorName(C.av_color_space_name(C.enum_AVColorSpace(s)), "AVColorSpace", int(s))
+}
+
+// MarshalText encodes the color space by its FFmpeg name, or as a number if FFmpeg has none.
+func (s AVColorSpace) MarshalText() ([]byte, error) {
+	return colorText(C.av_color_space_name(C.enum_AVColorSpace(s)), int(s)), nil
+}
+
+// UnmarshalText decodes an FFmpeg color space name, e.g. bt2020nc, or a numeric value.
+func (s *AVColorSpace) UnmarshalText(text []byte) error {
+	v, err := parseColorName(text, "AVColorSpace", func(name *C.char) C.int { return C.av_color_space_from_name(name) })
+	*s = AVColorSpace(v)
+	return err
+}
+
+// String returns the FFmpeg name of the chroma location, e.g. left or topleft.
+func (l AVChromaLocation) String() string {
+	return colorName(C.av_chroma_location_name(C.enum_AVChromaLocation(l)), "AVChromaLocation", int(l))
+}
+
+// MarshalText encodes the chroma location by its FFmpeg name, or as a number if FFmpeg has none.
+func (l AVChromaLocation) MarshalText() ([]byte, error) {
+	return colorText(C.av_chroma_location_name(C.enum_AVChromaLocation(l)), int(l)), nil
+}
+
+// UnmarshalText decodes an FFmpeg chroma location name, e.g. left, or a numeric value.
+func (l *AVChromaLocation) UnmarshalText(text []byte) error {
+	v, err := parseColorName(text, "AVChromaLocation", func(name *C.char) C.int { return C.av_chroma_location_from_name(name) })
+	*l = AVChromaLocation(v)
+	return err
+}
+
+// colorName converts the result of an av_*_name function, falling back to
+// "<type>(<value>)" for values FFmpeg has no name for.
+func colorName(name *C.char, typ string, v int) string {
+	if name == nil {
+		return typ + "(" + strconv.Itoa(v) + ")"
+	}
+	return C.GoString(name)
+}
+
+// colorText is the MarshalText form of a color value: the result of an
+// av_*_name function, or the decimal value so that it can be parsed back.
+func colorText(name *C.char, v int) []byte {
+	if name == nil {
+		return strconv.AppendInt(nil, int64(v), 10)
+	}
+	return []byte(C.GoString(name))
+}
+
+// parseColorName looks up text with the given av_*_from_name function. A
+// decimal number is accepted as the raw value.
+func parseColorName(text []byte, typ string, fromName func(*C.char) C.int) (int, error) {
+	if v, err := strconv.Atoi(string(text)); err == nil {
+		if v < 0 {
+			return 0, fmt.Errorf("invalid %s %q", typ, text)
+		}
+		return v, nil
+	}
+	cname := C.CString(string(text))
+	defer C.free(unsafe.Pointer(cname))
+	v := fromName(cname)
+	if v < 0 {
+		return 0, fmt.Errorf("unknown %s %q", typ, text)
+	}
+	return int(v), nil
+}
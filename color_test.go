@@ -0,0 +1,99 @@
+package mediafileinfo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestColor_String(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{AVCOL_RANGE_MPEG.String(), "tv"},
+		{AVCOL_RANGE_JPEG.String(), "pc"},
+		{AVCOL_PRI_BT2020.String(), "bt2020"},
+		{AVCOL_TRC_SMPTE2084.String(), "smpte2084"},
+		{AVCOL_TRC_ARIB_STD_B67.String(), "arib-std-b67"},
+		{AVCOL_SPC_BT2020_NCL.String(), "bt2020nc"},
+		{AVCHROMA_LOC_TOPLEFT.String(), "topleft"},
+		{AVColorPrimaries(99).String(), "AVColorPrimaries(99)"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("String() = %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestColor_JSON(t *testing.T) {
+	in := AVCodecParameters{
+		ColorRange:     AVCOL_RANGE_MPEG,
+		ColorPrimaries: AVCOL_PRI_BT2020,
+		ColorTrc:       AVCOL_TRC_SMPTE2084,
+		ColorSpace:     AVCOL_SPC_BT2020_NCL,
+		ChromaLocation: AVCHROMA_LOC_LEFT,
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if fields["color_trc"] != "smpte2084" || fields["color_range"] != "tv" {
+		t.Errorf("Expected color names in JSON, got %s", data)
+	}
+
+	var out AVCodecParameters
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if out.ColorRange != in.ColorRange || out.ColorPrimaries != in.ColorPrimaries ||
+		out.ColorTrc != in.ColorTrc || out.ColorSpace != in.ColorSpace || out.ChromaLocation != in.ChromaLocation {
+		t.Errorf("Round trip = %+v, want %+v", out, in)
+	}
+
+	var trc AVColorTransferCharacteristic
+	if err := trc.UnmarshalText([]byte("no-such-trc")); err == nil {
+		t.Errorf("Expected error for unknown transfer characteristic")
+	}
+}
+
+func TestColor_JSON_RGBAndUnknown(t *testing.T) {
+	in := AVCodecParameters{
+		ColorSpace:     AVCOL_SPC_RGB,
+		ColorPrimaries: AVColorPrimaries(99),
+		ColorTrc:       AVColorTransferCharacteristic(200),
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if _, ok := fields["color_space"]; !ok {
+		t.Errorf("Expected color_space for AVCOL_SPC_RGB in JSON, got %s", data)
+	}
+	if fields["color_primaries"] != "99" || fields["color_trc"] != "200" {
+		t.Errorf("Expected unknown values as numbers in JSON, got %s", data)
+	}
+
+	var out AVCodecParameters
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if out.ColorSpace != in.ColorSpace || out.ColorPrimaries != in.ColorPrimaries || out.ColorTrc != in.ColorTrc {
+		t.Errorf("Round trip = %+v, want %+v", out, in)
+	}
+
+	var r AVColorRange
+	if err := r.UnmarshalText([]byte("-1")); err == nil {
+		t.Errorf("Expected error for negative color range")
+	}
+}
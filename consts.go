@@ -16,6 +16,9 @@ const (
 	AVMEDIA_TYPE_NB         AVMediaType = 5
 )
 
+// AV_NOPTS_VALUE marks an undefined timestamp, e.g. the StartTime or Duration of a stream whose start or duration is unknown.
+const AV_NOPTS_VALUE int64 = -1 << 63
+
 // AVFieldOrder indicates a progressive video or describes the field order for interlaced video. To get a textual representation call String() on the const
 //   - PROGRESSIVE
 //   - TT: Top coded_first, top displayed first.
@@ -35,6 +38,168 @@ const (
 	AV_FIELD_BT                              // Bottom coded first, top displayed first.
 )
 
+// AVColorRange describes the range of the color values, limited "tv" (MPEG) or full "pc" (JPEG) range.
+// To get a textual representation call String() on the const
+type AVColorRange int
+
+const (
+	AVCOL_RANGE_UNSPECIFIED AVColorRange = iota // unknown
+	AVCOL_RANGE_MPEG                            // tv: limited range, 16-235 for 8 bit luma.
+	AVCOL_RANGE_JPEG                            // pc: full range, 0-255 for 8 bit.
+)
+
+// AVColorPrimaries defines the chromaticity coordinates of the source primaries (ISO/IEC 23091-2).
+// To get a textual representation (e.g. bt709, bt2020) call String() on the const
+type AVColorPrimaries int
+
+const (
+	AVCOL_PRI_RESERVED0   AVColorPrimaries = 0
+	AVCOL_PRI_BT709       AVColorPrimaries = 1 // also ITU-R BT1361 / IEC 61966-2-4 / SMPTE RP 177 Annex B
+	AVCOL_PRI_UNSPECIFIED AVColorPrimaries = 2
+	AVCOL_PRI_RESERVED    AVColorPrimaries = 3
+	AVCOL_PRI_BT470M      AVColorPrimaries = 4  // also FCC Title 47 Code of Federal Regulations 73.682 (a)(20)
+	AVCOL_PRI_BT470BG     AVColorPrimaries = 5  // also ITU-R BT601-6 625 / PAL & SECAM
+	AVCOL_PRI_SMPTE170M   AVColorPrimaries = 6  // also ITU-R BT601-6 525 / NTSC
+	AVCOL_PRI_SMPTE240M   AVColorPrimaries = 7  // identical to above, also called "SMPTE C"
+	AVCOL_PRI_FILM        AVColorPrimaries = 8  // colour filters using Illuminant C
+	AVCOL_PRI_BT2020      AVColorPrimaries = 9  // ITU-R BT2020
+	AVCOL_PRI_SMPTE428    AVColorPrimaries = 10 // SMPTE ST 428-1 (CIE 1931 XYZ)
+	AVCOL_PRI_SMPTE431    AVColorPrimaries = 11 // SMPTE ST 431-2 (2011) / DCI P3
+	AVCOL_PRI_SMPTE432    AVColorPrimaries = 12 // SMPTE ST 432-1 (2010) / P3 D65 / Display P3
+	AVCOL_PRI_EBU3213     AVColorPrimaries = 22 // EBU Tech. 3213-E / JEDEC P22 phosphors
+)
+
+// AVColorTransferCharacteristic defines the opto-electronic transfer function of the source (ISO/IEC 23091-2).
+// To get a textual representation (e.g. smpte2084, arib-std-b67) call String() on the const
+type AVColorTransferCharacteristic int
+
+const (
+	AVCOL_TRC_RESERVED0    AVColorTransferCharacteristic = 0
+	AVCOL_TRC_BT709        AVColorTransferCharacteristic = 1 // also ITU-R BT1361
+	AVCOL_TRC_UNSPECIFIED  AVColorTransferCharacteristic = 2
+	AVCOL_TRC_RESERVED     AVColorTransferCharacteristic = 3
+	AVCOL_TRC_GAMMA22      AVColorTransferCharacteristic = 4  // also ITU-R BT470M / PAL & SECAM
+	AVCOL_TRC_GAMMA28      AVColorTransferCharacteristic = 5  // also ITU-R BT470BG
+	AVCOL_TRC_SMPTE170M    AVColorTransferCharacteristic = 6  // also ITU-R BT601-6 525 or 625 / NTSC
+	AVCOL_TRC_SMPTE240M    AVColorTransferCharacteristic = 7  // SMPTE 240M
+	AVCOL_TRC_LINEAR       AVColorTransferCharacteristic = 8  // Linear transfer characteristics
+	AVCOL_TRC_LOG          AVColorTransferCharacteristic = 9  // Logarithmic transfer characteristic (100:1 range)
+	AVCOL_TRC_LOG_SQRT     AVColorTransferCharacteristic = 10 // Logarithmic transfer characteristic (100 * Sqrt(10) : 1 range)
+	AVCOL_TRC_IEC61966_2_4 AVColorTransferCharacteristic = 11 // IEC 61966-2-4
+	AVCOL_TRC_BT1361_ECG   AVColorTransferCharacteristic = 12 // ITU-R BT1361 Extended Colour Gamut
+	AVCOL_TRC_IEC61966_2_1 AVColorTransferCharacteristic = 13 // IEC 61966-2-1 (sRGB or sYCC)
+	AVCOL_TRC_BT2020_10    AVColorTransferCharacteristic = 14 // ITU-R BT2020 for 10-bit system
+	AVCOL_TRC_BT2020_12    AVColorTransferCharacteristic = 15 // ITU-R BT2020 for 12-bit system
+	AVCOL_TRC_SMPTE2084    AVColorTransferCharacteristic = 16 // SMPTE ST 2084 (PQ) for 10-, 12-, 14- and 16-bit systems
+	AVCOL_TRC_SMPTE428     AVColorTransferCharacteristic = 17 // SMPTE ST 428-1
+	AVCOL_TRC_ARIB_STD_B67 AVColorTransferCharacteristic = 18 // ARIB STD-B67, known as "Hybrid log-gamma" (HLG)
+)
+
+// AVColorSpace describes the YUV colorspace, i.e. the matrix coefficients (ISO/IEC 23091-2).
+// To get a textual representation (e.g. bt709, bt2020nc) call String() on the const
+type AVColorSpace int
+
+const (
+	AVCOL_SPC_RGB                AVColorSpace = 0 // order of coefficients is actually GBR, also sRGB
+	AVCOL_SPC_BT709              AVColorSpace = 1 // also ITU-R BT1361 / IEC 61966-2-4 xvYCC709
+	AVCOL_SPC_UNSPECIFIED        AVColorSpace = 2
+	AVCOL_SPC_RESERVED           AVColorSpace = 3
+	AVCOL_SPC_FCC                AVColorSpace = 4  // FCC Title 47 Code of Federal Regulations 73.682 (a)(20)
+	AVCOL_SPC_BT470BG            AVColorSpace = 5  // also ITU-R BT601-6 625 / PAL & SECAM / xvYCC601
+	AVCOL_SPC_SMPTE170M          AVColorSpace = 6  // also ITU-R BT601-6 525 / NTSC
+	AVCOL_SPC_SMPTE240M          AVColorSpace = 7  // derived from 170M primaries and D65 white point
+	AVCOL_SPC_YCGCO              AVColorSpace = 8  // used by Dirac / VC-2 and H.264 FRext
+	AVCOL_SPC_BT2020_NCL         AVColorSpace = 9  // ITU-R BT2020 non-constant luminance system
+	AVCOL_SPC_BT2020_CL          AVColorSpace = 10 // ITU-R BT2020 constant luminance system
+	AVCOL_SPC_SMPTE2085          AVColorSpace = 11 // SMPTE 2085, Y'D'zD'x
+	AVCOL_SPC_CHROMA_DERIVED_NCL AVColorSpace = 12 // Chromaticity-derived non-constant luminance system
+	AVCOL_SPC_CHROMA_DERIVED_CL  AVColorSpace = 13 // Chromaticity-derived constant luminance system
+	AVCOL_SPC_ICTCP              AVColorSpace = 14 // ITU-R BT.2100-0, ICtCp
+	AVCOL_SPC_IPT_C2             AVColorSpace = 15 // SMPTE ST 2128, IPT-C2
+	AVCOL_SPC_YCGCO_RE           AVColorSpace = 16 // YCgCo-R, even addition of bits
+	AVCOL_SPC_YCGCO_RO           AVColorSpace = 17 // YCgCo-R, odd addition of bits
+)
+
+// AVChromaLocation describes the location of the chroma samples relative to the luma samples.
+// To get a textual representation (e.g. left, topleft) call String() on the const
+type AVChromaLocation int
+
+const (
+	AVCHROMA_LOC_UNSPECIFIED AVChromaLocation = iota
+	AVCHROMA_LOC_LEFT                         // MPEG-2/4 4:2:0, H.264 default for 4:2:0
+	AVCHROMA_LOC_CENTER                       // MPEG-1 4:2:0, JPEG 4:2:0, H.263 4:2:0
+	AVCHROMA_LOC_TOPLEFT                      // ITU-R 601, SMPTE 274M 296M S314M(DV 4:1:1), mpeg2 4:2:2
+	AVCHROMA_LOC_TOP
+	AVCHROMA_LOC_BOTTOMLEFT
+	AVCHROMA_LOC_BOTTOM
+)
+
+// AVPixelFormat identifies the pixel format of a video stream, see PixelFormatByName for lookups.
+// To get a textual representation (e.g. yuv420p) call String() on the value
+type AVPixelFormat int
+
+const (
+	AV_PIX_FMT_NONE    AVPixelFormat = -1 // No or unknown pixel format.
+	AV_PIX_FMT_YUV420P AVPixelFormat = 0  // Planar YUV 4:2:0, 12bpp.
+)
+
+// AVSampleFormat identifies the sample format of an audio stream. To get a textual representation (e.g. fltp) call String() on the const
+type AVSampleFormat int
+
+const (
+	AV_SAMPLE_FMT_NONE AVSampleFormat = iota - 1 // No or unknown sample format.
+	AV_SAMPLE_FMT_U8                             // Unsigned 8 bits.
+	AV_SAMPLE_FMT_S16                            // Signed 16 bits.
+	AV_SAMPLE_FMT_S32                            // Signed 32 bits.
+	AV_SAMPLE_FMT_FLT                            // Float.
+	AV_SAMPLE_FMT_DBL                            // Double.
+	AV_SAMPLE_FMT_U8P                            // Unsigned 8 bits, planar.
+	AV_SAMPLE_FMT_S16P                           // Signed 16 bits, planar.
+	AV_SAMPLE_FMT_S32P                           // Signed 32 bits, planar.
+	AV_SAMPLE_FMT_FLTP                           // Float, planar.
+	AV_SAMPLE_FMT_DBLP                           // Double, planar.
+	AV_SAMPLE_FMT_S64                            // Signed 64 bits.
+	AV_SAMPLE_FMT_S64P                           // Signed 64 bits, planar.
+)
+
+// AVChannelOrder describes how the channels of an AVChannelLayout are ordered. To get a textual representation call String() on the const
+//
+//go:generate stringer -type=AVChannelOrder -trimprefix=AV_CHANNEL_ORDER_
+type AVChannelOrder int
+
+const (
+	AV_CHANNEL_ORDER_UNSPEC    AVChannelOrder = iota // Only the channel count is known.
+	AV_CHANNEL_ORDER_NATIVE                          // Channels are in the order of the AVChannel values, described by a bitmask.
+	AV_CHANNEL_ORDER_CUSTOM                          // Channels are in an explicitly given order.
+	AV_CHANNEL_ORDER_AMBISONIC                       // Ambisonic channels in ACN order, optionally followed by non-diegetic channels.
+)
+
+// Disposition is a bitmask of AV_DISPOSITION_* flags describing the intended use of a stream,
+// e.g. the default or forced track. To get a textual representation call String() on the value
+type Disposition uint32
+
+const (
+	AV_DISPOSITION_DEFAULT          Disposition = 1 << 0  // Stream should be chosen by default among streams of the same type.
+	AV_DISPOSITION_DUB              Disposition = 1 << 1  // Stream is not in the original language.
+	AV_DISPOSITION_ORIGINAL         Disposition = 1 << 2  // Stream is in the original language.
+	AV_DISPOSITION_COMMENT          Disposition = 1 << 3  // Stream is a commentary track.
+	AV_DISPOSITION_LYRICS           Disposition = 1 << 4  // Stream contains song lyrics.
+	AV_DISPOSITION_KARAOKE          Disposition = 1 << 5  // Stream contains karaoke audio.
+	AV_DISPOSITION_FORCED           Disposition = 1 << 6  // Track should be used even if the user did not select it.
+	AV_DISPOSITION_HEARING_IMPAIRED Disposition = 1 << 7  // Stream is intended for hearing impaired audiences.
+	AV_DISPOSITION_VISUAL_IMPAIRED  Disposition = 1 << 8  // Stream is intended for visually impaired audiences.
+	AV_DISPOSITION_CLEAN_EFFECTS    Disposition = 1 << 9  // Audio stream contains music and sound effects without voice.
+	AV_DISPOSITION_ATTACHED_PIC     Disposition = 1 << 10 // Stream is a single picture such as cover art.
+	AV_DISPOSITION_TIMED_THUMBNAILS Disposition = 1 << 11 // Stream is sparse thumbnail images.
+	AV_DISPOSITION_NON_DIEGETIC     Disposition = 1 << 12 // Audio stream is not part of the scene, e.g. a narrator.
+	AV_DISPOSITION_CAPTIONS         Disposition = 1 << 16 // Subtitle stream contains captions.
+	AV_DISPOSITION_DESCRIPTIONS     Disposition = 1 << 17 // Subtitle stream contains textual descriptions of the video content.
+	AV_DISPOSITION_METADATA         Disposition = 1 << 18 // Subtitle stream contains time-aligned metadata.
+	AV_DISPOSITION_DEPENDENT        Disposition = 1 << 19 // Stream is intended to be mixed with another stream before presentation.
+	AV_DISPOSITION_STILL_IMAGE      Disposition = 1 << 20 // Video stream contains still images.
+	AV_DISPOSITION_MULTILAYER       Disposition = 1 << 21 // Video stream contains multiple layers, e.g. stereoscopic views.
+)
+
 // CodecID identifies a codec by its integer ID. To get a textual representation call String() on the const
 //
 //go:generate stringer -type=CodecID -trimprefix=CODEC_ID_
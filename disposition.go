@@ -0,0 +1,106 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// dispositionNames lists the flags in bit order with the names FFmpeg uses (av_disposition_to_string).
+var dispositionNames = []struct {
+	flag Disposition
+	name string
+}{
+	{AV_DISPOSITION_DEFAULT, "default"},
+	{AV_DISPOSITION_DUB, "dub"},
+	{AV_DISPOSITION_ORIGINAL, "original"},
+	{AV_DISPOSITION_COMMENT, "comment"},
+	{AV_DISPOSITION_LYRICS, "lyrics"},
+	{AV_DISPOSITION_KARAOKE, "karaoke"},
+	{AV_DISPOSITION_FORCED, "forced"},
+	{AV_DISPOSITION_HEARING_IMPAIRED, "hearing_impaired"},
+	{AV_DISPOSITION_VISUAL_IMPAIRED, "visual_impaired"},
+	{AV_DISPOSITION_CLEAN_EFFECTS, "clean_effects"},
+	{AV_DISPOSITION_ATTACHED_PIC, "attached_pic"},
+	{AV_DISPOSITION_TIMED_THUMBNAILS, "timed_thumbnails"},
+	{AV_DISPOSITION_NON_DIEGETIC, "non_diegetic"},
+	{AV_DISPOSITION_CAPTIONS, "captions"},
+	{AV_DISPOSITION_DESCRIPTIONS, "descriptions"},
+	{AV_DISPOSITION_METADATA, "metadata"},
+	{AV_DISPOSITION_DEPENDENT, "dependent"},
+	{AV_DISPOSITION_STILL_IMAGE, "still_image"},
+	{AV_DISPOSITION_MULTILAYER, "multilayer"},
+}
+
+// Flags returns the names of all set flags, e.g. ["default", "forced"].
+func (d Disposition) Flags() []string {
+	flags := []string{}
+	for _, n := range dispositionNames {
+		if d&n.flag != 0 {
+			flags = append(flags, n.name)
+		}
+	}
+	return flags
+}
+
+// String returns the set flags joined by "+" (e.g. "default+forced") or "none".
+func (d Disposition) String() string {
+	if d == 0 {
+		return "none"
+	}
+	return strings.Join(d.Flags(), "+")
+}
+
+// MarshalJSON encodes the disposition as list of flag names.
+func (d Disposition) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Flags())
+}
+
+// UnmarshalJSON decodes a list of flag names as written by MarshalJSON.
+func (d *Disposition) UnmarshalJSON(data []byte) error {
+	var flags []string
+	if err := json.Unmarshal(data, &flags); err != nil {
+		return err
+	}
+	var v Disposition
+	for _, f := range flags {
+		found := false
+		for _, n := range dispositionNames {
+			if n.name == f {
+				v |= n.flag
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown disposition flag: %s", f)
+		}
+	}
+	*d = v
+	return nil
+}
+
+// Has reports whether all flags of flag are set.
+func (d Disposition) Has(flag Disposition) bool {
+	return d&flag == flag
+}
+
+// IsDefault reports whether the stream should be chosen by default.
+func (d Disposition) IsDefault() bool { return d.Has(AV_DISPOSITION_DEFAULT) }
+
+// IsForced reports whether the track should be shown even if not selected, e.g. forced subtitles.
+func (d Disposition) IsForced() bool { return d.Has(AV_DISPOSITION_FORCED) }
+
+// IsHearingImpaired reports whether the stream is intended for hearing impaired audiences.
+func (d Disposition) IsHearingImpaired() bool { return d.Has(AV_DISPOSITION_HEARING_IMPAIRED) }
+
+// IsVisualImpaired reports whether the stream is intended for visually impaired audiences.
+func (d Disposition) IsVisualImpaired() bool { return d.Has(AV_DISPOSITION_VISUAL_IMPAIRED) }
+
+// IsAttachedPic reports whether the stream is a single picture such as cover art.
+func (d Disposition) IsAttachedPic() bool { return d.Has(AV_DISPOSITION_ATTACHED_PIC) }
+
+// IsCaptions reports whether the subtitle stream contains captions.
+func (d Disposition) IsCaptions() bool { return d.Has(AV_DISPOSITION_CAPTIONS) }
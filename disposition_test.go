@@ -0,0 +1,58 @@
+package mediafileinfo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDisposition_String(t *testing.T) {
+	tests := []struct {
+		d    Disposition
+		want string
+	}{
+		{0, "none"},
+		{AV_DISPOSITION_DEFAULT, "default"},
+		{AV_DISPOSITION_DEFAULT | AV_DISPOSITION_FORCED, "default+forced"},
+		{AV_DISPOSITION_ATTACHED_PIC, "attached_pic"},
+		{AV_DISPOSITION_HEARING_IMPAIRED | AV_DISPOSITION_CAPTIONS, "hearing_impaired+captions"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.d.String(); got != tt.want {
+			t.Errorf("Disposition(%#x).String() = %q, want %q", uint32(tt.d), got, tt.want)
+		}
+	}
+}
+
+func TestDisposition_Helpers(t *testing.T) {
+	d := AV_DISPOSITION_DEFAULT | AV_DISPOSITION_CAPTIONS
+	if !d.IsDefault() || !d.IsCaptions() {
+		t.Errorf("%v: Expected IsDefault and IsCaptions", d)
+	}
+	if d.IsForced() || d.IsAttachedPic() || d.IsHearingImpaired() || d.IsVisualImpaired() {
+		t.Errorf("%v: Expected no other flags", d)
+	}
+}
+
+func TestDisposition_JSON(t *testing.T) {
+	d := AV_DISPOSITION_DEFAULT | AV_DISPOSITION_FORCED
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if string(data) != `["default","forced"]` {
+		t.Errorf("json.Marshal = %s, want [\"default\",\"forced\"]", data)
+	}
+
+	var back Disposition
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if back != d {
+		t.Errorf("json.Unmarshal = %v, want %v", back, d)
+	}
+
+	if err := json.Unmarshal([]byte(`["bogus"]`), &back); err == nil {
+		t.Error("Expected an error for an unknown flag")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Sentinel errors to test a *ProbeError against with errors.Is.
+var (
+	ErrNotExist          = errors.New("file does not exist")
+	ErrInvalidData       = errors.New("invalid data found when processing input")
+	ErrPermission        = errors.New("permission denied")
+	ErrUnsupportedFormat = errors.New("unsupported format")
+)
+
+// ProbeStage names the libavformat call which failed while probing a file.
+type ProbeStage string
+
+const (
+	StageOpenInput      ProbeStage = "open_input"       // avformat_open_input
+	StageFindStreamInfo ProbeStage = "find_stream_info" // avformat_find_stream_info
+	StageReadFrame      ProbeStage = "read_frame"       // av_read_frame
+)
+
+// ProbeError is returned when FFmpeg fails to open or analyze a media file.
+// It supports errors.Is against ErrNotExist, ErrInvalidData, ErrPermission and
+// ErrUnsupportedFormat as well as fs.ErrNotExist and fs.ErrPermission.
+type ProbeError struct {
+	Filename string     // Name of the file or reader.
+	Stage    ProbeStage // Step which failed.
+	Code     int        // Raw AVERROR code (negative).
+	Message  string     // Error text from av_strerror.
+	Err      error      // Underlying error of a custom reader, may be nil.
+}
+
+// Error returns the error in the form "<stage> <filename>: <message>".
+func (e *ProbeError) Error() string {
+	msg := e.Message
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+	return fmt.Sprintf("%s %s: %s", e.Stage, e.Filename, msg)
+}
+
+// Unwrap returns the error of a custom reader if there is one.
+func (e *ProbeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the AVERROR code corresponds to target.
+func (e *ProbeError) Is(target error) bool {
+	switch target {
+	case ErrNotExist, fs.ErrNotExist:
+		return e.Code == C.AVERROR_ENOENT || e.Code == C.AVERROR_HTTP_NOT_FOUND
+	case ErrInvalidData:
+		return e.Code == C.AVERROR_INVALIDDATA
+	case ErrPermission, fs.ErrPermission:
+		return e.Code == C.AVERROR_EACCES || e.Code == C.AVERROR_EPERM ||
+			e.Code == C.AVERROR_HTTP_FORBIDDEN || e.Code == C.AVERROR_HTTP_UNAUTHORIZED
+	case ErrUnsupportedFormat:
+		return e.Code == C.AVERROR_DEMUXER_NOT_FOUND || e.Code == C.AVERROR_DECODER_NOT_FOUND ||
+			e.Code == C.AVERROR_PROTOCOL_NOT_FOUND || e.Code == C.AVERROR_PATCHWELCOME
+	}
+	return false
+}
+
+// newProbeError creates a ProbeError for the AVERROR code returned in the given stage.
+func newProbeError(filename string, stage C.int, code C.int, err error) *ProbeError {
+	pe := &ProbeError{
+		Filename: filename,
+		Stage:    StageOpenInput,
+		Code:     int(code),
+		Message:  avErrorText(code),
+		Err:      err,
+	}
+	switch stage {
+	case C.PROBE_STAGE_FIND_STREAM_INFO:
+		pe.Stage = StageFindStreamInfo
+	case C.PROBE_STAGE_READ_FRAME:
+		pe.Stage = StageReadFrame
+	}
+	return pe
+}
+
+// avErrorText returns the description of an AVERROR code.
+func avErrorText(code C.int) string {
+	var buf [C.AV_ERROR_MAX_STRING_SIZE]C.char
+	C.av_strerror(code, &buf[0], C.size_t(len(buf)))
+	return C.GoString(&buf[0])
+}
@@ -0,0 +1,67 @@
+package mediafileinfo
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestProbeError_NotExist(t *testing.T) {
+	_, err := GetMediaInfo("testdata/does-not-exist.mp4")
+	if err == nil {
+		t.Fatal("Expected an error for a missing file")
+	}
+
+	var pe *ProbeError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Expected *ProbeError, got %T", err)
+	}
+	if pe.Stage != StageOpenInput {
+		t.Errorf("Stage = %q, want %q", pe.Stage, StageOpenInput)
+	}
+	if pe.Code >= 0 {
+		t.Errorf("Code = %d, want a negative AVERROR", pe.Code)
+	}
+	if pe.Message == "" {
+		t.Error("Expected a non-empty Message")
+	}
+	if !errors.Is(err, ErrNotExist) {
+		t.Errorf("errors.Is(%v, ErrNotExist) = false", err)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("errors.Is(%v, fs.ErrNotExist) = false", err)
+	}
+	if errors.Is(err, ErrInvalidData) {
+		t.Errorf("errors.Is(%v, ErrInvalidData) = true", err)
+	}
+	if !strings.HasPrefix(err.Error(), "open_input testdata/does-not-exist.mp4: ") {
+		t.Errorf("unexpected error text %q", err.Error())
+	}
+}
+
+func TestProbeError_InvalidData(t *testing.T) {
+	_, err := GetMediaInfoFromReader(bytes.NewReader(bytes.Repeat([]byte{0}, 4096)), "garbage")
+	if !errors.Is(err, ErrInvalidData) {
+		t.Errorf("errors.Is(%v, ErrInvalidData) = false", err)
+	}
+}
+
+func TestProbeError_UnsupportedFormat(t *testing.T) {
+	_, err := GetMediaInfoWithOptions("testdata/sample.avi", &ProbeOptions{InputFormat: "no-such-format"})
+	if !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("errors.Is(%v, ErrUnsupportedFormat) = false", err)
+	}
+}
+
+func TestProbeError_Unwrap(t *testing.T) {
+	readErr := errors.New("connection reset")
+	pe := &ProbeError{Filename: "upload", Stage: StageOpenInput, Message: "I/O error", Err: readErr}
+	if !errors.Is(pe, readErr) {
+		t.Error("Expected errors.Is to find the reader error")
+	}
+	if got, want := pe.Error(), "open_input upload: connection reset"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -5,6 +5,8 @@ package mediafileinfo
 import (
 	"fmt"
 	"math"
+	"strings"
+	"time"
 )
 
 // FormatBytes converts an int64 value of bytes into a human-readable string using KB, MB, GB, or TB (1024 basis).
@@ -56,3 +58,35 @@ func FormatDurationMS(ms uint64) string {
 		return fmt.Sprintf("%d.%03d", seconds, ms)
 	}
 }
+
+// FormatFourCC renders a codec tag as FourCC string like av_fourcc_make_string does,
+// e.g. 0x31637661 -> "avc1". Characters other than letters, digits, space, '.', '_'
+// and '-' are shown as their decimal value in brackets, e.g. "[1][0][0][0]".
+// Returns an empty string for a zero tag.
+func FormatFourCC(tag uint32) string {
+	if tag == 0 {
+		return ""
+	}
+	var s strings.Builder
+	for i := range 4 {
+		c := byte(tag >> (8 * i))
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9',
+			c == ' ', c == '.', c == '_', c == '-':
+			s.WriteByte(c)
+		default:
+			fmt.Fprintf(&s, "[%d]", c)
+		}
+	}
+	return s.String()
+}
+
+// FormatDuration converts a time.Duration to a human-readable string with millisecond
+// precision in the same layout as FormatDurationMS. Negative durations, e.g. the start
+// time of a stream with encoder delay, are prefixed with '-'.
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatDurationMS(uint64(-d.Milliseconds()))
+	}
+	return FormatDurationMS(uint64(d.Milliseconds()))
+}
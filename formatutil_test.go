@@ -3,6 +3,7 @@ package mediafileinfo
 
 import (
 	"testing"
+	"time"
 )
 
 func TestFormatBytes(t *testing.T) {
@@ -57,3 +58,45 @@ func TestFormatDurationMS(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{0, "0.000"},
+		{1500 * time.Microsecond, "0.001"},
+		{90 * time.Second, "1:30.000"},
+		{time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond, "1:02:03.004"},
+		{-21 * time.Millisecond, "-0.021"},
+	}
+
+	for _, tt := range tests {
+		got := FormatDuration(tt.in)
+		if got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatFourCC(t *testing.T) {
+	tests := []struct {
+		in   uint32
+		want string
+	}{
+		{0, ""},
+		{0x31637661, "avc1"},
+		{0x31637668, "hvc1"},
+		{0x31766568, "hev1"},
+		{0x44495658, "XVID"},
+		{0x20776172, "raw "},
+		{0x00000001, "[1][0][0][0]"},
+	}
+
+	for _, tt := range tests {
+		got := FormatFourCC(tt.in)
+		if got != tt.want {
+			t.Errorf("FormatFourCC(%#x) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
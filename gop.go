@@ -0,0 +1,104 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+import "time"
+
+// GOPReport describes the keyframes and the group of pictures (GOP) structure of a
+// video stream, see ProbeOptions.AnalyzeGOP. A GOP starts at a keyframe and lasts
+// until the next one; lengths are counted in packets, i.e. frames.
+type GOPReport struct {
+	GOPs            []GOP         // All GOPs in decoding order.
+	MinLength       int           // Number of frames of the shortest GOP.
+	MaxLength       int           // Number of frames of the longest GOP.
+	AvgLength       float64       // Average number of frames per GOP.
+	ClosedGOPs      int           // Number of GOPs which can be decoded on their own.
+	OpenGOPs        int           // Number of GOPs with frames referencing the previous GOP.
+	LongestInterval time.Duration // Longest distance between two keyframes (or the stream end).
+	LeadingFrames   int           `json:",omitempty"` // Frames before the first keyframe, not decodable on their own.
+}
+
+// GOP is a single group of pictures, starting at a keyframe.
+type GOP struct {
+	StartPTS  int64         // PTS of the keyframe in stream time_base units.
+	StartTime time.Duration // PTS of the keyframe as time.
+	Pos       int64         // Byte offset of the keyframe, -1 if unknown.
+	Frames    int           // Number of frames including the keyframe.
+	Duration  time.Duration // Time until the next keyframe or the end of the stream.
+	Open      bool          // Frames following the keyframe in decoding order are shown before it (open GOP).
+}
+
+// KeyframeTimes returns the presentation times of all keyframes, e.g. as cut points.
+func (r *GOPReport) KeyframeTimes() []time.Duration {
+	times := make([]time.Duration, len(r.GOPs))
+	for i, g := range r.GOPs {
+		times[i] = g.StartTime
+	}
+	return times
+}
+
+// gopBuilder collects the GOPs of one video stream from its packets in decoding order.
+type gopBuilder struct {
+	gops    []GOP
+	leading int
+	end     time.Duration // largest presentation end time seen so far
+	hasEnd  bool          // end was set by a packet with a timestamp
+}
+
+// add accounts the packet to the current GOP or starts a new one at a keyframe.
+func (b *gopBuilder) add(p Packet) {
+	pts, ptsTime := p.PTS, p.PTSTime
+	if pts == AV_NOPTS_VALUE {
+		pts, ptsTime = p.DTS, p.DTSTime
+	}
+	if pts != AV_NOPTS_VALUE {
+		if end := ptsTime + p.DurationTime; !b.hasEnd || end > b.end {
+			b.end, b.hasEnd = end, true
+		}
+	}
+
+	if p.Key {
+		b.gops = append(b.gops, GOP{StartPTS: pts, StartTime: ptsTime, Pos: p.Pos, Frames: 1})
+		return
+	}
+	if len(b.gops) == 0 {
+		b.leading++
+		return
+	}
+	g := &b.gops[len(b.gops)-1]
+	g.Frames++
+	if pts != AV_NOPTS_VALUE && g.StartPTS != AV_NOPTS_VALUE && pts < g.StartPTS {
+		g.Open = true
+	}
+}
+
+// report computes the durations and statistics of the collected GOPs. Returns
+// nil if the stream had no keyframe.
+func (b *gopBuilder) report() *GOPReport {
+	if len(b.gops) == 0 {
+		return nil
+	}
+
+	r := &GOPReport{GOPs: b.gops, LeadingFrames: b.leading, MinLength: b.gops[0].Frames}
+	frames := 0
+	for i := range r.GOPs {
+		g := &r.GOPs[i]
+		if i+1 < len(r.GOPs) {
+			g.Duration = r.GOPs[i+1].StartTime - g.StartTime
+		} else {
+			g.Duration = max(b.end-g.StartTime, 0)
+		}
+
+		frames += g.Frames
+		r.MinLength = min(r.MinLength, g.Frames)
+		r.MaxLength = max(r.MaxLength, g.Frames)
+		r.LongestInterval = max(r.LongestInterval, g.Duration)
+		if g.Open {
+			r.OpenGOPs++
+		} else {
+			r.ClosedGOPs++
+		}
+	}
+	r.AvgLength = float64(frames) / float64(len(r.GOPs))
+	return r
+}
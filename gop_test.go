@@ -0,0 +1,103 @@
+package mediafileinfo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGOPBuilder(t *testing.T) {
+	// time base 1/25: one tick is 40ms
+	pkt := func(pts int64, key bool) Packet {
+		return Packet{PTS: pts, PTSTime: time.Duration(pts) * 40 * time.Millisecond, DTS: pts,
+			Duration: 1, DurationTime: 40 * time.Millisecond, Pos: pts * 100, Key: key}
+	}
+
+	b := &gopBuilder{}
+	b.add(pkt(-1, false)) // leading frame without keyframe
+	// closed GOP in decoding order I0 P3 B1 B2
+	for _, p := range []Packet{pkt(0, true), pkt(3, false), pkt(1, false), pkt(2, false)} {
+		b.add(p)
+	}
+	// open GOP: leading B-frames 4 and 5 are shown before the keyframe 6
+	for _, p := range []Packet{pkt(6, true), pkt(4, false), pkt(5, false), pkt(9, false), pkt(7, false), pkt(8, false)} {
+		b.add(p)
+	}
+	b.add(pkt(10, true))
+
+	r := b.report()
+	if r == nil {
+		t.Fatal("Expected a report")
+	}
+	if len(r.GOPs) != 3 {
+		t.Fatalf("got %d GOPs, want 3", len(r.GOPs))
+	}
+	if r.LeadingFrames != 1 {
+		t.Errorf("LeadingFrames = %d, want 1", r.LeadingFrames)
+	}
+	if r.MinLength != 1 || r.MaxLength != 6 || r.AvgLength != 11.0/3 {
+		t.Errorf("Min/Max/AvgLength = %d/%d/%v, want 1/6/%v", r.MinLength, r.MaxLength, r.AvgLength, 11.0/3)
+	}
+	if r.ClosedGOPs != 2 || r.OpenGOPs != 1 || r.GOPs[0].Open || !r.GOPs[1].Open {
+		t.Errorf("Closed/OpenGOPs = %d/%d, want 2/1 with the second GOP open", r.ClosedGOPs, r.OpenGOPs)
+	}
+	if r.GOPs[1].Pos != 600 || r.GOPs[1].StartPTS != 6 {
+		t.Errorf("GOP 1: Pos/StartPTS = %d/%d, want 600/6", r.GOPs[1].Pos, r.GOPs[1].StartPTS)
+	}
+	if r.LongestInterval != 240*time.Millisecond {
+		t.Errorf("LongestInterval = %v, want 240ms", r.LongestInterval)
+	}
+	// last GOP lasts until the end of its only frame
+	if r.GOPs[2].Duration != 40*time.Millisecond {
+		t.Errorf("GOP 2: Duration = %v, want 40ms", r.GOPs[2].Duration)
+	}
+
+	want := []time.Duration{0, 240 * time.Millisecond, 400 * time.Millisecond}
+	for i, kt := range r.KeyframeTimes() {
+		if kt != want[i] {
+			t.Errorf("KeyframeTimes()[%d] = %v, want %v", i, kt, want[i])
+		}
+	}
+
+	if (&gopBuilder{}).report() != nil {
+		t.Errorf("Expected no report without packets")
+	}
+}
+
+func TestGOPBuilder_NegativeTimestamps(t *testing.T) {
+	// stream entirely before 0, e.g. after an edit list: -10 .. -7 ticks of 40ms
+	b := &gopBuilder{}
+	for pts := int64(-10); pts < -6; pts++ {
+		b.add(Packet{PTS: pts, PTSTime: time.Duration(pts) * 40 * time.Millisecond, DTS: pts,
+			Duration: 1, DurationTime: 40 * time.Millisecond, Key: pts == -10})
+	}
+
+	r := b.report()
+	if r == nil || len(r.GOPs) != 1 {
+		t.Fatalf("Expected a report with one GOP, got %+v", r)
+	}
+	if r.GOPs[0].Duration != 160*time.Millisecond {
+		t.Errorf("Duration = %v, want 160ms", r.GOPs[0].Duration)
+	}
+}
+
+func TestGetMediaInfoWithOptions_AnalyzeGOP(t *testing.T) {
+	info, err := GetMediaInfoWithOptions("testdata/sample.avi", &ProbeOptions{AnalyzeGOP: true})
+	if err != nil {
+		t.Fatalf("GetMediaInfoWithOptions returned error: %v", err)
+	}
+
+	gop := info.Streams[0].GOP
+	if gop == nil {
+		t.Fatal("video: Expected a GOPReport")
+	}
+	// FFVHUFF is intra only: every frame is a closed GOP of its own
+	if gop.MinLength != 1 || gop.MaxLength != 1 || gop.OpenGOPs != 0 {
+		t.Errorf("Min/MaxLength/OpenGOPs = %d/%d/%d, want 1/1/0", gop.MinLength, gop.MaxLength, gop.OpenGOPs)
+	}
+	if gop.LongestInterval != 40*time.Millisecond {
+		t.Errorf("LongestInterval = %v, want 40ms at 25 fps", gop.LongestInterval)
+	}
+	if info.Streams[1].GOP != nil {
+		t.Errorf("audio: Expected no GOPReport")
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"context"
+	"fmt"
+	"runtime/cgo"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// liveInputs counts the inputContexts whose resources are not released yet,
+// so that tests can check for leaks.
+var liveInputs atomic.Int64
+
+// inputContext owns an opened C AVFormatContext together with the resources
+// that have to live as long as it: the handle of a custom Go reader and the
+// interrupt flag bound to a context.Context.
+type inputContext struct {
+	fc        *C.AVFormatContext
+	handle    cgo.Handle // 0 if libavformat does the I/O itself
+	interrupt *C.int     // nil if the context can never be canceled
+	stop      func() bool
+	mu        sync.Mutex // guards closed against the context.AfterFunc callback
+	closed    bool
+}
+
+// openInput opens url, or src if not nil, and reads the stream information
+// according to opts, which may be nil. A cancelable ctx is wired to the
+// interrupt callback of the format context so that cancellation aborts
+// blocking libavformat calls; ctx.Err() is returned then. All other failures
+// are reported as *ProbeError.
+func openInput(ctx context.Context, url string, src *avioSource, opts *ProbeOptions) (*inputContext, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fc := C.avformat_alloc_context()
+	if fc == nil {
+		return nil, fmt.Errorf("could not allocate format context for %s", url)
+	}
+	in := &inputContext{}
+	liveInputs.Add(1)
+
+	if src != nil {
+		in.handle = cgo.NewHandle(src)
+		seekable := C.int(0)
+		if src.seeker != nil {
+			seekable = 1
+		}
+		if ret := C.Attach_go_reader(fc, C.uintptr_t(in.handle), seekable); ret < 0 {
+			C.avformat_free_context(fc)
+			in.release()
+			return nil, fmt.Errorf("could not create I/O context for %s", url)
+		}
+	}
+
+	if ctx.Done() != nil {
+		in.interrupt = (*C.int)(C.calloc(1, C.size_t(unsafe.Sizeof(C.int(0)))))
+		C.Set_interrupt_flag(fc, in.interrupt)
+		in.stop = context.AfterFunc(ctx, func() {
+			in.mu.Lock()
+			defer in.mu.Unlock()
+			if !in.closed {
+				C.Raise_interrupt_flag(in.interrupt)
+			}
+		})
+	}
+
+	curl := C.CString(url)
+	defer C.free(unsafe.Pointer(curl))
+
+	var cformat *C.char
+	if name := opts.inputFormat(); name != "" {
+		cformat = C.CString(name)
+		defer C.free(unsafe.Pointer(cformat))
+	}
+
+	dict := opts.dictionary()
+	defer C.av_dict_free(&dict)
+
+	var stage C.int
+	if ret := C.Open_avformat_context(&fc, curl, cformat, &dict, &stage); ret < 0 {
+		in.release()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var srcErr error
+		if src != nil {
+			srcErr = src.err
+		}
+		return nil, newProbeError(url, stage, ret, srcErr)
+	}
+	in.fc = fc
+
+	if err := ctx.Err(); err != nil {
+		in.close()
+		return nil, err
+	}
+	return in, nil
+}
+
+// close frees the format context and all resources bound to it.
+func (in *inputContext) close() {
+	C.Free_avformat_context(in.fc)
+	in.fc = nil
+	in.release()
+}
+
+// sourceErr returns the first read or seek error of the custom reader, nil if
+// there was none or libavformat does the I/O itself.
+func (in *inputContext) sourceErr() error {
+	if in.handle == 0 {
+		return nil
+	}
+	return in.handle.Value().(*avioSource).err
+}
+
+// release frees everything but the format context itself.
+func (in *inputContext) release() {
+	if in.stop != nil {
+		in.stop()
+	}
+	if in.interrupt != nil {
+		in.mu.Lock()
+		in.closed = true
+		in.mu.Unlock()
+		C.free(unsafe.Pointer(in.interrupt))
+		in.interrupt = nil
+	}
+	if in.handle != 0 {
+		in.handle.Delete()
+		in.handle = 0
+	}
+	liveInputs.Add(-1)
+}
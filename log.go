@@ -0,0 +1,168 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"runtime/cgo"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	installLogCallback sync.Once
+	logHandler         atomic.Pointer[slog.Handler]
+)
+
+// SetLogHandler routes FFmpeg's internal log messages (e.g. "moov atom not found")
+// to h instead of stderr. Each record carries the FFmpeg component which logged
+// it as "component" attribute. Passing nil restores FFmpeg's default output.
+func SetLogHandler(h slog.Handler) {
+	installLogCallback.Do(func() { C.Install_log_callback() })
+	if h == nil {
+		logHandler.Store(nil)
+		C.Set_log_forward(0)
+		return
+	}
+	logHandler.Store(&h)
+	C.Set_log_forward(1)
+}
+
+// SetLogLevel sets the most verbose level FFmpeg emits (av_log_set_level).
+// FFmpeg's default corresponds to slog.LevelInfo.
+func SetLogLevel(level slog.Level) {
+	C.av_log_set_level(C.int(slogToAVLogLevel(level)))
+}
+
+// avLogLevelToSlog maps an AV_LOG_* level to a slog.Level.
+func avLogLevelToSlog(level int) slog.Level {
+	switch {
+	case level <= C.AV_LOG_ERROR:
+		return slog.LevelError
+	case level <= C.AV_LOG_WARNING:
+		return slog.LevelWarn
+	case level <= C.AV_LOG_INFO:
+		return slog.LevelInfo
+	case level <= C.AV_LOG_VERBOSE:
+		return slog.LevelDebug
+	default:
+		return slog.LevelDebug - 4
+	}
+}
+
+// slogToAVLogLevel maps a slog.Level to the corresponding AV_LOG_* level.
+func slogToAVLogLevel(level slog.Level) int {
+	switch {
+	case level >= slog.LevelError:
+		return C.AV_LOG_ERROR
+	case level >= slog.LevelWarn:
+		return C.AV_LOG_WARNING
+	case level >= slog.LevelInfo:
+		return C.AV_LOG_INFO
+	case level >= slog.LevelDebug:
+		return C.AV_LOG_VERBOSE
+	case level >= slog.LevelDebug-4:
+		return C.AV_LOG_DEBUG
+	default:
+		return C.AV_LOG_TRACE
+	}
+}
+
+// logCollector gathers the warnings and errors FFmpeg logs during one probe.
+type logCollector struct {
+	mu       sync.Mutex
+	messages []string
+	handle   cgo.Handle
+	prev     C.uintptr_t
+}
+
+// startLogCollector collects the warnings logged on the calling thread until stop
+// is called. The goroutine is locked to its OS thread meanwhile, so messages
+// logged by FFmpeg's worker threads are not collected.
+func startLogCollector() *logCollector {
+	installLogCallback.Do(func() { C.Install_log_callback() })
+	runtime.LockOSThread()
+	c := &logCollector{}
+	c.handle = cgo.NewHandle(c)
+	c.prev = C.Set_log_collector(C.uintptr_t(c.handle))
+	return c
+}
+
+// stop ends the collection and returns the collected messages.
+func (c *logCollector) stop() []string {
+	C.Set_log_collector(c.prev)
+	runtime.UnlockOSThread()
+	c.handle.Delete()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.messages
+}
+
+// since returns the messages collected after the first n.
+func (c *logCollector) since(n int) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n >= len(c.messages) {
+		return nil
+	}
+	return append([]string(nil), c.messages[n:]...)
+}
+
+// take returns the messages collected so far and discards them, so that a long
+// collection does not have to keep every message.
+func (c *logCollector) take() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	messages := c.messages
+	c.messages = nil
+	return messages
+}
+
+// count returns the number of messages collected so far.
+func (c *logCollector) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.messages)
+}
+
+//export goLogMessage
+func goLogMessage(collector C.uintptr_t, forward C.int, level C.int, component *C.char, line *C.char) {
+	msg := strings.TrimSpace(C.GoString(line))
+	if msg == "" {
+		return
+	}
+	comp := C.GoString(component)
+
+	if collector != 0 {
+		c := cgo.Handle(collector).Value().(*logCollector)
+		c.mu.Lock()
+		c.messages = append(c.messages, fmt.Sprintf("[%s] %s", comp, msg))
+		c.mu.Unlock()
+	}
+
+	if forward == 0 || level > C.av_log_get_level() {
+		return
+	}
+	h := logHandler.Load()
+	if h == nil {
+		return
+	}
+	lvl := avLogLevelToSlog(int(level))
+	ctx := context.Background()
+	if !(*h).Enabled(ctx, lvl) {
+		return
+	}
+	r := slog.NewRecord(time.Now(), lvl, msg, 0)
+	r.AddAttrs(slog.String("component", comp))
+	(*h).Handle(ctx, r)
+}
@@ -0,0 +1,103 @@
+package mediafileinfo
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordHandler is a slog.Handler which keeps all records.
+type recordHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func TestAVLogLevelToSlog(t *testing.T) {
+	tests := []struct {
+		level int
+		want  slog.Level
+	}{
+		{0, slog.LevelError},  // AV_LOG_PANIC
+		{16, slog.LevelError}, // AV_LOG_ERROR
+		{24, slog.LevelWarn},  // AV_LOG_WARNING
+		{32, slog.LevelInfo},  // AV_LOG_INFO
+		{40, slog.LevelDebug}, // AV_LOG_VERBOSE
+		{48, slog.LevelDebug - 4},
+	}
+
+	for _, tt := range tests {
+		got := avLogLevelToSlog(tt.level)
+		if got != tt.want {
+			t.Errorf("avLogLevelToSlog(%d) = %v, want %v", tt.level, got, tt.want)
+		}
+		if back := slogToAVLogLevel(got); tt.level >= 16 && tt.level <= 48 && back != tt.level {
+			t.Errorf("slogToAVLogLevel(%v) = %d, want %d", got, back, tt.level)
+		}
+	}
+}
+
+func TestSetLogHandler(t *testing.T) {
+	h := &recordHandler{}
+	SetLogHandler(h)
+	defer SetLogHandler(nil)
+
+	// An unparsable demuxer option makes FFmpeg log an error.
+	opts := &ProbeOptions{InputFormat: "s16le", DemuxerOptions: map[string]string{"sample_rate": "abc"}}
+	if _, err := GetMediaInfoWithOptions("testdata/sample.avi", opts); err == nil {
+		t.Fatal("Expected an error for an invalid demuxer option")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.records) == 0 {
+		t.Fatal("Expected FFmpeg log records")
+	}
+	if h.records[0].Level != slog.LevelError {
+		t.Errorf("Level = %v, want %v", h.records[0].Level, slog.LevelError)
+	}
+}
+
+func TestCollectWarnings(t *testing.T) {
+	// Raw PCM has no duration in a header, FFmpeg warns that it estimates it from the bitrate.
+	opts := &ProbeOptions{
+		InputFormat:     "s16le",
+		DemuxerOptions:  map[string]string{"sample_rate": "48000", "channels": "1"},
+		CollectWarnings: true,
+	}
+	info, err := GetMediaInfoWithOptions("testdata/sample.avi", opts)
+	if err != nil {
+		t.Fatalf("GetMediaInfoWithOptions returned error: %v", err)
+	}
+	if len(info.Warnings) == 0 {
+		t.Fatal("Expected collected warnings")
+	}
+	if !slices.ContainsFunc(info.Warnings, func(w string) bool {
+		return strings.Contains(w, "Estimating duration from bitrate")
+	}) {
+		t.Errorf("Warnings = %q, want the bitrate estimation warning", info.Warnings)
+	}
+
+	// without the option nothing is collected
+	opts.CollectWarnings = false
+	info, err = GetMediaInfoWithOptions("testdata/sample.avi", opts)
+	if err != nil {
+		t.Fatalf("GetMediaInfoWithOptions returned error: %v", err)
+	}
+	if len(info.Warnings) != 0 {
+		t.Errorf("Warnings = %q, want none without CollectWarnings", info.Warnings)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+import "time"
+
+// Measurement holds the frame count and timing measured by reading all packets,
+// see ProbeOptions.Accurate. It is independent of the values the container
+// header declares, which are often missing or wrong for AVI, raw streams or
+// broken files; the *Diff fields report the discrepancy.
+type Measurement struct {
+	Frames         int64         // Number of packets; a video packet carries one frame, an audio packet a block of samples.
+	FirstPTS       int64         // Smallest PTS in stream time_base units (AV_TIME_BASE for the container), AV_NOPTS_VALUE if there is none.
+	LastPTS        int64         // Largest PTS in the same units, AV_NOPTS_VALUE if there is none.
+	StartTime      time.Duration // Presentation time of the first frame.
+	EndTime        time.Duration // Presentation time of the last frame plus its duration.
+	Duration       time.Duration // Measured duration, EndTime - StartTime.
+	DurationText   string        // measured duration in hrs:min:sec.ms
+	FrameCountDiff int64         `json:",omitempty"` // Frames minus the frame count of the header, 0 if the header has none.
+	DurationDiff   time.Duration `json:",omitempty"` // Duration minus the duration of the header, 0 if the header has none.
+}
+
+// measureBuilder measures the packets of one stream.
+type measureBuilder struct {
+	frames      int64
+	first, last int64
+	start, end  time.Duration
+}
+
+func newMeasureBuilder() *measureBuilder {
+	return &measureBuilder{first: AV_NOPTS_VALUE, last: AV_NOPTS_VALUE}
+}
+
+// add accounts one packet; packets without PTS fall back to their DTS.
+func (b *measureBuilder) add(p Packet) {
+	b.frames++
+	pts, ptsTime := p.PTS, p.PTSTime
+	if pts == AV_NOPTS_VALUE {
+		pts, ptsTime = p.DTS, p.DTSTime
+	}
+	if pts == AV_NOPTS_VALUE {
+		return
+	}
+	if end := ptsTime + p.DurationTime; b.first == AV_NOPTS_VALUE || end > b.end {
+		b.end = end
+	}
+	if b.first == AV_NOPTS_VALUE || pts < b.first {
+		b.first, b.start = pts, ptsTime
+	}
+	if b.last == AV_NOPTS_VALUE || pts > b.last {
+		b.last = pts
+	}
+}
+
+// report compares the measurement with the frame count and duration of the
+// stream header. Returns nil if the stream had no packet.
+func (b *measureBuilder) report(s *AVStream) *Measurement {
+	if b.frames == 0 {
+		return nil
+	}
+	m := &Measurement{Frames: b.frames, FirstPTS: b.first, LastPTS: b.last}
+	if b.first != AV_NOPTS_VALUE {
+		m.StartTime, m.EndTime = b.start, max(b.end, b.start)
+		m.Duration = m.EndTime - m.StartTime
+	}
+	m.DurationText = FormatDuration(m.Duration)
+	if s.NbFrames > 0 {
+		m.FrameCountDiff = m.Frames - s.NbFrames
+	}
+	if s.Duration != AV_NOPTS_VALUE {
+		m.DurationDiff = m.Duration - s.DurationTime
+	}
+	return m
+}
+
+// measureContainer combines the measurements of the streams into the one of the
+// container and compares it with the container header.
+func measureContainer(info *AVFormatContext) *Measurement {
+	var m *Measurement
+	for _, s := range info.Streams {
+		sm := s.Measured
+		if sm == nil {
+			continue
+		}
+		if m == nil {
+			m = &Measurement{FirstPTS: AV_NOPTS_VALUE, LastPTS: AV_NOPTS_VALUE}
+		}
+		m.Frames += sm.Frames
+		if sm.FirstPTS == AV_NOPTS_VALUE {
+			continue
+		}
+		first := m.FirstPTS == AV_NOPTS_VALUE
+		if first || sm.StartTime < m.StartTime {
+			m.StartTime = sm.StartTime
+			m.FirstPTS = sm.StartTime.Microseconds()
+		}
+		lastTime := s.TimeBase.ToDuration(sm.LastPTS)
+		if m.LastPTS == AV_NOPTS_VALUE || lastTime.Microseconds() > m.LastPTS {
+			m.LastPTS = lastTime.Microseconds()
+		}
+		if first || sm.EndTime > m.EndTime {
+			m.EndTime = sm.EndTime
+		}
+	}
+	if m == nil {
+		return nil
+	}
+
+	if m.FirstPTS != AV_NOPTS_VALUE {
+		m.Duration = m.EndTime - m.StartTime
+	}
+	m.DurationText = FormatDuration(m.Duration)
+	if info.Duration != AV_NOPTS_VALUE {
+		m.DurationDiff = m.Duration - info.DurationTime
+	}
+	return m
+}
@@ -0,0 +1,137 @@
+package mediafileinfo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMeasureBuilder(t *testing.T) {
+	// time base 1/1000, B-frames make the PTS non-monotonic
+	b := newMeasureBuilder()
+	for _, pts := range []int64{100, 400, 200, 300} {
+		b.add(Packet{PTS: pts, PTSTime: time.Duration(pts) * time.Millisecond, Duration: 100, DurationTime: 100 * time.Millisecond})
+	}
+	b.add(Packet{PTS: AV_NOPTS_VALUE, DTS: AV_NOPTS_VALUE})
+
+	s := &AVStream{NbFrames: 4, Duration: 300, DurationTime: 300 * time.Millisecond}
+	m := b.report(s)
+	if m == nil {
+		t.Fatal("Expected a measurement")
+	}
+	if m.Frames != 5 || m.FrameCountDiff != 1 {
+		t.Errorf("Frames/FrameCountDiff = %d/%d, want 5/1", m.Frames, m.FrameCountDiff)
+	}
+	if m.FirstPTS != 100 || m.LastPTS != 400 {
+		t.Errorf("FirstPTS/LastPTS = %d/%d, want 100/400", m.FirstPTS, m.LastPTS)
+	}
+	if m.StartTime != 100*time.Millisecond || m.EndTime != 500*time.Millisecond || m.Duration != 400*time.Millisecond {
+		t.Errorf("Start/End/Duration = %v/%v/%v, want 100ms/500ms/400ms", m.StartTime, m.EndTime, m.Duration)
+	}
+	if m.DurationDiff != 100*time.Millisecond {
+		t.Errorf("DurationDiff = %v, want 100ms", m.DurationDiff)
+	}
+
+	// unknown header values are not compared
+	m = b.report(&AVStream{Duration: AV_NOPTS_VALUE})
+	if m.FrameCountDiff != 0 || m.DurationDiff != 0 {
+		t.Errorf("FrameCountDiff/DurationDiff = %d/%v, want 0 without header values", m.FrameCountDiff, m.DurationDiff)
+	}
+
+	if newMeasureBuilder().report(s) != nil {
+		t.Errorf("Expected no measurement without packets")
+	}
+}
+
+func TestMeasureBuilder_NegativeTimestamps(t *testing.T) {
+	// stream entirely before 0, e.g. after an edit list: -500ms .. -200ms
+	b := newMeasureBuilder()
+	for _, pts := range []int64{-500, -400, -300} {
+		b.add(Packet{PTS: pts, PTSTime: time.Duration(pts) * time.Millisecond, Duration: 100, DurationTime: 100 * time.Millisecond})
+	}
+
+	s := AVStream{TimeBase: AVRational{Num: 1, Den: 1000}, Duration: AV_NOPTS_VALUE}
+	m := b.report(&s)
+	if m.StartTime != -500*time.Millisecond || m.EndTime != -200*time.Millisecond || m.Duration != 300*time.Millisecond {
+		t.Errorf("Start/End/Duration = %v/%v/%v, want -500ms/-200ms/300ms", m.StartTime, m.EndTime, m.Duration)
+	}
+
+	s.Measured = m
+	c := measureContainer(&AVFormatContext{Streams: []AVStream{s}, Duration: AV_NOPTS_VALUE})
+	if c.EndTime != -200*time.Millisecond || c.Duration != 300*time.Millisecond {
+		t.Errorf("container End/Duration = %v/%v, want -200ms/300ms", c.EndTime, c.Duration)
+	}
+}
+
+// failingReader serves the first n bytes of r and fails afterwards.
+type failingReader struct {
+	r io.Reader
+	n int
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if f.n <= 0 {
+		return 0, errors.New("device unplugged")
+	}
+	n, err := f.r.Read(p[:min(len(p), f.n)])
+	f.n -= n
+	return n, err
+}
+
+func TestGetMediaInfoWithOptions_ReadError(t *testing.T) {
+	data, err := os.ReadFile("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("could not read test file: %v", err)
+	}
+
+	src := &avioSource{r: &failingReader{r: bytes.NewReader(data), n: len(data) / 2}, size: -1}
+	info, err := getMediaInfo(context.Background(), "sample.avi", src, &ProbeOptions{Accurate: true, AnalyzeGOP: true})
+	if err != nil {
+		t.Fatalf("getMediaInfo returned error: %v", err)
+	}
+	if !strings.Contains(info.ReadError, "device unplugged") {
+		t.Errorf("ReadError = %q, want the reader error", info.ReadError)
+	}
+
+	video := info.Streams[0]
+	if video.Measured == nil || video.Measured.Frames == 0 || video.Measured.Frames >= video.NbFrames {
+		t.Errorf("video: Measured = %+v, want the frames before the error", video.Measured)
+	}
+	if video.GOP == nil {
+		t.Errorf("video: Expected the GOPs before the error")
+	}
+	if info.Measured == nil {
+		t.Errorf("Expected a container Measurement")
+	}
+}
+
+func TestGetMediaInfoWithOptions_Accurate(t *testing.T) {
+	info, err := GetMediaInfoWithOptions("testdata/sample.avi", &ProbeOptions{Accurate: true})
+	if err != nil {
+		t.Fatalf("GetMediaInfoWithOptions returned error: %v", err)
+	}
+
+	video := info.Streams[0]
+	m := video.Measured
+	if m == nil {
+		t.Fatal("video: Expected a Measurement")
+	}
+	if m.Frames != video.NbFrames || m.FrameCountDiff != 0 {
+		t.Errorf("video: Frames = %d (diff %d), want %d from the AVI header", m.Frames, m.FrameCountDiff, video.NbFrames)
+	}
+	if want := time.Duration(m.Frames) * 40 * time.Millisecond; m.Duration != want {
+		t.Errorf("video: Duration = %v, want %v at 25 fps", m.Duration, want)
+	}
+	if info.Streams[1].Measured == nil {
+		t.Errorf("audio: Expected a Measurement")
+	}
+
+	if info.Measured == nil || info.Measured.Frames != m.Frames+info.Streams[1].Measured.Frames {
+		t.Errorf("Measured = %+v, want the sum of the streams", info.Measured)
+	}
+}
@@ -18,33 +18,40 @@ package mediafileinfo
 #cgo CFLAGS: -I./source
 #cgo LDFLAGS: -L./source -lavformat -lavcodec -lavutil
 #include "mediainfowrapper.h"
-#include <stdlib.h>
 */
 import "C"
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
-	"unsafe"
+	"time"
 )
 
-const avTimeBase = 10000 // time in ms
-
 // AVFormatContext represents the format context for a media file, mirroring FFmpeg's AVFormatContext.
 // See: https://ffmpeg.org/doxygen/trunk/structAVFormatContext.html
 type AVFormatContext struct {
-	Filename       string     // Name of the media file.
-	FileExt        string     // File externsion e.g. mp4
-	FileSize       int64      // File size
-	FileSizeText   string     // File size in MB or GB
-	StartTime      int64      // Start time of the stream in AV_TIME_BASE units.
-	Duration       uint64     // Duration of the stream in AV_TIME_BASE units.
-	DurationText   string     // duration in hrs:min:sec.ms
-	BitRate        uint64     // Total bitrate of the file in bits per second.
-	FormatName     string     // Short name of the format.
-	FormatLongName string     // Long name of the format.
-	Streams        []AVStream // List of all streams in the file.
+	Filename       string            // Name of the media file.
+	FileExt        string            // File externsion e.g. mp4
+	FileSize       int64             // File size
+	FileSizeText   string            // File size in MB or GB
+	StartTime      int64             // Start time of the file in AV_TIME_BASE units (microseconds), AV_NOPTS_VALUE if unknown.
+	StartTimeTime  time.Duration     // Start time of the file as time, 0 if unknown.
+	StartTimeText  string            // start time in hrs:min:sec.ms or N/A
+	Duration       int64             // Duration of the file in AV_TIME_BASE units (microseconds), AV_NOPTS_VALUE if unknown.
+	DurationTime   time.Duration     // Duration of the file as time, 0 if unknown.
+	DurationText   string            // duration in hrs:min:sec.ms or N/A
+	BitRate        uint64            // Total bitrate of the file in bits per second.
+	FormatName     string            // Short name of the format.
+	FormatLongName string            // Long name of the format.
+	Streams        []AVStream        // List of all streams in the file.
+	Chapters       []AVChapter       `json:",omitempty"` // List of all chapters in the file.
+	Programs       []AVProgram       `json:",omitempty"` // List of all programs, e.g. of an MPEG-TS.
+	Measured       *Measurement      `json:",omitempty"` // Frame count and timing from all packets, see ProbeOptions.Accurate.
+	Metadata       map[string]string `json:",omitempty"` // Container metadata tags, e.g. title, encoder or creation_time.
+	Warnings       []string          `json:",omitempty"` // FFmpeg warnings logged while probing, see ProbeOptions.CollectWarnings.
+	ReadError      string            `json:",omitempty"` // Error which stopped the packet scan of AnalyzeGOP or Accurate before the end of the file.
 }
 
 // AVStream represents a single stream (audio, video, subtitles, etc.) in a media file, similar to FFmpeg's AVStream.
@@ -53,11 +60,22 @@ type AVStream struct {
 	Index             int                // Stream index in AVFormatContext.
 	ID                int                // Format-specific stream ID.
 	TimeBase          AVRational         // Time base for the stream timestamps.
-	Duration          int64              // Duration of the stream in stream time_base units.
-	DurationText      string             // duration in hrs:min:sec.ms
+	StartTime         int64              // Presentation time of the first frame in stream time_base units, AV_NOPTS_VALUE if unknown.
+	StartTimeTime     time.Duration      // Presentation time of the first frame as time, 0 if unknown.
+	StartTimeText     string             // start time in hrs:min:sec.ms or N/A
+	Duration          int64              // Duration of the stream in stream time_base units, AV_NOPTS_VALUE if unknown.
+	DurationTime      time.Duration      // Duration of the stream as time, 0 if unknown.
+	DurationText      string             // duration in hrs:min:sec.ms or N/A
 	SampleAspectRatio AVRational         // Sample aspect ratio (width/height) for video.
 	AverageFrameRate  AVRational         // Average frame rate.
+	RealFrameRate     AVRational         // Real base frame rate, the lowest rate all timestamps can be represented in (r_frame_rate).
+	NbFrames          int64              // Number of frames as stored in the container, 0 if unknown.
 	CodecParameters   *AVCodecParameters // Codec parameters for this stream.
+	Disposition       Disposition        `json:",omitempty"` // Intended use of the stream, e.g. default or forced.
+	SideData          *CodedSideData     `json:",omitempty"` // Decoded coded side data, e.g. rotation or HDR metadata.
+	GOP               *GOPReport         `json:",omitempty"` // Keyframes and GOP structure, see ProbeOptions.AnalyzeGOP.
+	Measured          *Measurement       `json:",omitempty"` // Frame count and timing from all packets, see ProbeOptions.Accurate.
+	Metadata          map[string]string  `json:",omitempty"` // Stream metadata tags, e.g. language or handler_name.
 }
 
 // AVRational represents a rational number, as used in FFmpeg for time bases and aspect ratios.
@@ -76,42 +94,58 @@ func (a AVRational) String() string {
 // AVCodecParameters describes the properties of a single codec context.
 // See: https://ffmpeg.org/doxygen/trunk/structAVCodecParameters.html
 type AVCodecParameters struct {
-	CodecType          AVMediaType  // General type of the encoded data (see AVMediaType).
-	CodecTypeText      string       // General type as text
-	CodecID            CodecID      // Specific type of the encoded data (the codec used).
-	CodecIDText        string       // used codec as text
-	CodecTag           uint32       // Additional information about the codec (corresponds to the AVI FOURCC).
-	ExtradataSize      int          `json:"extradata_size,omitempty"`     // Size of the extradata content in bytes.
-	NbCodedSideData    int          `json:"nb_coded_side_data,omitempty"` // Amount of entries in coded_side_data.
-	Format             int          // The pixel or sample format.
-	BitRate            int64        // The average bitrate of the encoded data (in bits per second).
-	BitsPerCodedSample int          `json:"bits_per_coded_sample,omitempty"` // The number of bits per sample in the codedwords.
-	BitsPerRawSample   int          `json:"bits_per_raw_sample,omitempty"`   // This is the number of valid bits in each output sample.
-	Profile            int          `json:"profile,omitempty"`               // Codec-specific bitstream restrictions that the stream conforms to.
-	Level              int          `json:"level,omitempty"`                 // Codec-specific level.
-	Width              int          `json:"width,omitempty"`                 // Video only: width of the video frame.
-	Height             int          `json:"height,omitempty"`                // Video only: height of the video frame.
-	AspectRatio        AVRational   // Video only: sample aspect ratio.
-	FieldOrder         AVFieldOrder // Video only: field order.
-	FieldOrderText     string       // Video only: field order as text
-	ColorRange         int          `json:"color_range,omitempty"`      // Video only: color range.
-	ColorPrimaries     int32        `json:"color_primaries,omitempty"`  // Video only: color primaries.
-	ColorTrc           int32        `json:"color_trc,omitempty"`        // Video only: color transfer characteristic.
-	ColorSpace         int32        `json:"color_space,omitempty"`      // Video only: YUV colorspace type.
-	ChromaLocation     int32        `json:"chroma_location,omitempty"`  // Video only: location of chroma samples.
-	Channels           int          `json:"channels,omitempty"`         // Audio only: number of audio channels.
-	VideoDelay         int          `json:"video_delay,omitempty"`      // Video only: number of frames the decoder should delay.
-	SampleRate         int          `json:"sample_rate,omitempty"`      // Audio only: sampling rate.
-	BlockAlign         int          `json:"block_align,omitempty"`      // Audio only: block alignment.
-	FrameSize          int          `json:"frame_size,omitempty"`       // Audio only: audio frame size.
-	InitialPadding     int          `json:"initial_padding,omitempty"`  // Audio only: initial padding.
-	TrailingPadding    int          `json:"trailing_padding,omitempty"` // Audio only: trailing padding.
-	SeekPreroll        int          `json:"seek_preroll,omitempty"`     // Audio only: seek preroll.
+	CodecType          AVMediaType                   // General type of the encoded data (see AVMediaType).
+	CodecTypeText      string                        // General type as text
+	CodecID            CodecID                       // Specific type of the encoded data (the codec used).
+	CodecIDText        string                        // used codec as text
+	CodecName          string                        // Short name of the codec, e.g. h264 or aac.
+	CodecLongName      string                        // Descriptive name of the codec, e.g. H.264 / AVC / MPEG-4 AVC / MPEG-4 part 10.
+	CodecProperties    CodecProperties               // Properties of the codec, e.g. lossless or intra only.
+	CodecTag           uint32                        // Additional information about the codec (corresponds to the AVI FOURCC).
+	CodecTagText       string                        // Codec tag as FourCC, e.g. avc1, hvc1 or XVID
+	ExtradataSize      int                           `json:"extradata_size,omitempty"`     // Size of the extradata content in bytes.
+	NbCodedSideData    int                           `json:"nb_coded_side_data,omitempty"` // Amount of entries in coded_side_data.
+	Format             int                           // The pixel or sample format.
+	FormatText         string                        // Pixel or sample format as text, e.g. yuv420p or fltp
+	BitRate            int64                         // The average bitrate of the encoded data (in bits per second).
+	BitsPerCodedSample int                           `json:"bits_per_coded_sample,omitempty"` // The number of bits per sample in the codedwords.
+	BitsPerRawSample   int                           `json:"bits_per_raw_sample,omitempty"`   // This is the number of valid bits in each output sample.
+	Profile            int                           `json:"profile,omitempty"`               // Codec-specific bitstream restrictions that the stream conforms to.
+	ProfileText        string                        `json:"profile_text,omitempty"`          // Profile name, e.g. High, Main 10, LC or HE-AACv2.
+	Level              int                           `json:"level,omitempty"`                 // Codec-specific level.
+	LevelText          string                        `json:"level_text,omitempty"`            // Level as written by the codec specification, e.g. 4.1.
+	Width              int                           `json:"width,omitempty"`                 // Video only: width of the video frame.
+	Height             int                           `json:"height,omitempty"`                // Video only: height of the video frame.
+	AspectRatio        AVRational                    // Video only: sample aspect ratio.
+	FieldOrder         AVFieldOrder                  // Video only: field order.
+	FieldOrderText     string                        // Video only: field order as text
+	ColorRange         AVColorRange                  `json:"color_range,omitempty"`      // Video only: color range.
+	ColorPrimaries     AVColorPrimaries              `json:"color_primaries,omitempty"`  // Video only: color primaries.
+	ColorTrc           AVColorTransferCharacteristic `json:"color_trc,omitempty"`        // Video only: color transfer characteristic.
+	ColorSpace         AVColorSpace                  `json:"color_space"`                // Video only: YUV colorspace type; 0 is RGB.
+	ChromaLocation     AVChromaLocation              `json:"chroma_location,omitempty"`  // Video only: location of chroma samples.
+	PixelFormat        *PixelFormatDescriptor        `json:"pixel_format,omitempty"`     // Video only: layout of the pixel format.
+	Channels           int                           `json:"channels,omitempty"`         // Audio only: number of audio channels.
+	ChannelLayout      *AVChannelLayout              `json:"channel_layout,omitempty"`   // Audio only: channel layout.
+	SampleFormat       *SampleFormatDescriptor       `json:"sample_format,omitempty"`    // Audio only: layout of the sample format.
+	VideoDelay         int                           `json:"video_delay,omitempty"`      // Video only: number of frames the decoder should delay.
+	SampleRate         int                           `json:"sample_rate,omitempty"`      // Audio only: sampling rate.
+	BlockAlign         int                           `json:"block_align,omitempty"`      // Audio only: block alignment.
+	FrameSize          int                           `json:"frame_size,omitempty"`       // Audio only: audio frame size.
+	InitialPadding     int                           `json:"initial_padding,omitempty"`  // Audio only: initial padding.
+	TrailingPadding    int                           `json:"trailing_padding,omitempty"` // Audio only: trailing padding.
+	SeekPreroll        int                           `json:"seek_preroll,omitempty"`     // Audio only: seek preroll.
 }
 
+// AVChannelLayout describes the order and meaning of the channels of an audio stream, mirroring FFmpeg's AVChannelLayout.
+// See: https://ffmpeg.org/doxygen/trunk/structAVChannelLayout.html
 type AVChannelLayout struct {
-	Order    int
-	Channels int
+	Order        AVChannelOrder // Channel order used in this layout.
+	OrderText    string         // Channel order as text
+	Channels     int            // Number of channels in this layout.
+	Mask         uint64         `json:",omitempty"` // Bitmask of the present channels for native and ambisonic layouts.
+	ChannelNames []string       `json:",omitempty"` // Name of each channel in order, e.g. FL, FR, FC, LFE.
+	Description  string         // Canonical description, e.g. "stereo", "5.1(side)" or "7.1.4".
 }
 
 // PrintAVContextJSON prints the AVFormatContext struct as formatted JSON to stdout.
@@ -127,17 +161,70 @@ func PrintAVContextJSON(params *AVFormatContext) error {
 
 // GetMediaInfo opens a media file and returns a MediaInfo.
 func GetMediaInfo(filename string) (*AVFormatContext, error) {
-	cfilename := C.CString(filename)
-	defer C.free(unsafe.Pointer(cfilename))
+	return getMediaInfo(context.Background(), filename, nil, nil)
+}
+
+// GetMediaInfoContext is like GetMediaInfo but aborts probing when ctx is canceled
+// or its deadline expires. In that case ctx.Err() is returned, i.e. context.Canceled
+// or context.DeadlineExceeded.
+func GetMediaInfoContext(ctx context.Context, filename string) (*AVFormatContext, error) {
+	return getMediaInfo(ctx, filename, nil, nil)
+}
+
+// GetMediaInfoWithOptions is like GetMediaInfo but probes the file according to opts,
+// e.g. with a larger probe size for MPEG-TS files with late streams or with a forced
+// demuxer for raw inputs. Options unknown to the demuxer are ignored.
+func GetMediaInfoWithOptions(filename string, opts *ProbeOptions) (*AVFormatContext, error) {
+	return getMediaInfo(context.Background(), filename, nil, opts)
+}
+
+// getMediaInfo opens filename, or src if not nil, and maps it to an AVFormatContext.
+func getMediaInfo(ctx context.Context, filename string, src *avioSource, opts *ProbeOptions) (*AVFormatContext, error) {
+	var collector *logCollector
+	if opts.collectWarnings() {
+		collector = startLogCollector()
+	}
+	in, err := openInput(ctx, filename, src, opts)
+	var warnings []string
+	if collector != nil {
+		warnings = collector.stop()
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer in.close()
+
+	formatCtx := newAVFormatContext(in.fc, filename)
+	formatCtx.Warnings = warnings
+
+	if opts.scanPackets() {
+		if err := analyzePackets(ctx, in, filename, formatCtx, opts); err != nil {
+			return nil, err
+		}
+	}
 
-	ctx := C.Get_avformat_context(cfilename)
+	if src != nil {
+		formatCtx.FileExt = filepath.Ext(filename)
+		if src.size >= 0 {
+			formatCtx.FileSize = src.size
+			formatCtx.FileSizeText = FormatBytes(src.size)
+		}
+		return formatCtx, nil
+	}
 
-	if ctx == nil {
-		return nil, fmt.Errorf("could not open file: %s", filename)
+	// Query file size and extension
+	if fi, err := os.Stat(filename); err == nil {
+		formatCtx.FileSize = fi.Size()
+		formatCtx.FileSizeText = FormatBytes(fi.Size())
+		formatCtx.FileExt = filepath.Ext(filename)
 	}
 
-	defer C.Free_avformat_context(ctx)
+	return formatCtx, nil
+}
 
+// newAVFormatContext maps an opened C AVFormatContext and its streams to the Go structures.
+// File size and extension are left to the caller as they depend on the input source.
+func newAVFormatContext(ctx *C.AVFormatContext, filename string) *AVFormatContext {
 	num := C.Get_stream_count(ctx)
 
 	var streams []AVStream
@@ -147,20 +234,50 @@ func GetMediaInfo(filename string) (*AVFormatContext, error) {
 		ctp := AVMediaType(int(s.codecpar.codec_type))
 		flo := AVFieldOrder(int(s.codecpar.field_order))
 		codecParams := &AVCodecParameters{
-			CodecType:      ctp,
-			CodecTypeText:  ctp.String(),
-			CodecID:        cid,
-			CodecIDText:    cid.String(),
-			BitRate:        int64(s.codecpar.bit_rate),
-			Width:          int(s.codecpar.width),
-			Height:         int(s.codecpar.height),
-			SampleRate:     int(s.codecpar.sample_rate),
-			Channels:       int(s.codecpar.ch_layout.nb_channels),
-			Format:         int(s.codecpar.format),
-			AspectRatio:    AVRational{Num: int(s.codecpar.sample_aspect_ratio.num), Den: int(s.codecpar.sample_aspect_ratio.den)},
-			FieldOrder:     flo,
-			FieldOrderText: flo.String(),
-			// ggf. weitere Felder
+			CodecType:          ctp,
+			CodecTypeText:      ctp.String(),
+			CodecID:            cid,
+			CodecIDText:        cid.String(),
+			CodecTag:           uint32(s.codecpar.codec_tag),
+			CodecTagText:       FormatFourCC(uint32(s.codecpar.codec_tag)),
+			ExtradataSize:      int(s.codecpar.extradata_size),
+			NbCodedSideData:    int(s.codecpar.nb_coded_side_data),
+			Format:             int(s.codecpar.format),
+			BitRate:            int64(s.codecpar.bit_rate),
+			BitsPerCodedSample: int(s.codecpar.bits_per_coded_sample),
+			BitsPerRawSample:   int(s.codecpar.bits_per_raw_sample),
+			Profile:            int(s.codecpar.profile),
+			Level:              int(s.codecpar.level),
+			Width:              int(s.codecpar.width),
+			Height:             int(s.codecpar.height),
+			AspectRatio:        AVRational{Num: int(s.codecpar.sample_aspect_ratio.num), Den: int(s.codecpar.sample_aspect_ratio.den)},
+			FieldOrder:         flo,
+			FieldOrderText:     flo.String(),
+			ColorRange:         AVColorRange(s.codecpar.color_range),
+			ColorPrimaries:     AVColorPrimaries(s.codecpar.color_primaries),
+			ColorTrc:           AVColorTransferCharacteristic(s.codecpar.color_trc),
+			ColorSpace:         AVColorSpace(s.codecpar.color_space),
+			ChromaLocation:     AVChromaLocation(s.codecpar.chroma_location),
+			Channels:           int(s.codecpar.ch_layout.nb_channels),
+			ChannelLayout:      newAVChannelLayout(&s.codecpar.ch_layout),
+			VideoDelay:         int(s.codecpar.video_delay),
+			SampleRate:         int(s.codecpar.sample_rate),
+			BlockAlign:         int(s.codecpar.block_align),
+			FrameSize:          int(s.codecpar.frame_size),
+			InitialPadding:     int(s.codecpar.initial_padding),
+			TrailingPadding:    int(s.codecpar.trailing_padding),
+			SeekPreroll:        int(s.codecpar.seek_preroll),
+		}
+		codecParams.setCodecDescriptor(s.codecpar.codec_id)
+		switch ctp {
+		case AVMEDIA_TYPE_VIDEO:
+			pixFmt := AVPixelFormat(codecParams.Format)
+			codecParams.FormatText = pixFmt.String()
+			codecParams.PixelFormat = pixFmt.Descriptor()
+		case AVMEDIA_TYPE_AUDIO:
+			sampleFmt := AVSampleFormat(codecParams.Format)
+			codecParams.FormatText = sampleFmt.String()
+			codecParams.SampleFormat = sampleFmt.Descriptor()
 		}
 
 		stream := AVStream{
@@ -168,36 +285,40 @@ func GetMediaInfo(filename string) (*AVFormatContext, error) {
 			ID:                int(s.id),
 			CodecParameters:   codecParams,
 			TimeBase:          AVRational{Num: int(s.time_base.num), Den: int(s.time_base.den)},
+			StartTime:         int64(s.start_time),
+			StartTimeTime:     ticksToDuration(s.start_time, s.time_base),
+			StartTimeText:     ticksToText(s.start_time, s.time_base),
 			Duration:          int64(s.duration),
-			DurationText:      FormatDurationMS(uint64(s.duration)),
+			DurationTime:      ticksToDuration(s.duration, s.time_base),
+			DurationText:      ticksToText(s.duration, s.time_base),
 			SampleAspectRatio: AVRational{Num: int(s.sample_aspect_ratio.num), Den: int(s.sample_aspect_ratio.den)},
 			AverageFrameRate:  AVRational{Num: int(s.avg_frame_rate.num), Den: int(s.avg_frame_rate.den)},
+			RealFrameRate:     newAVRational(s.r_frame_rate),
+			NbFrames:          int64(s.nb_frames),
+			Disposition:       Disposition(s.disposition),
+			SideData:          newCodedSideData(s.codecpar),
+			Metadata:          dictToMap(s.metadata),
 		}
 		streams = append(streams, stream)
 	}
 
-	// Query file size and extension
-	var fileSize int64
-	var fileExt string
-	if fi, err := os.Stat(filename); err == nil {
-		fileSize = fi.Size()
-		fileExt = filepath.Ext(filename)
-	}
-
 	// Map to FormatContext
 	fname := filepath.Base(filename)
-	formatCtx := &AVFormatContext{
+	return &AVFormatContext{
 		Filename:       fname,
 		Streams:        streams,
-		Duration:       uint64(ctx.duration) / avTimeBase,
-		DurationText:   FormatDurationMS(uint64(ctx.duration) / avTimeBase),
+		StartTime:      int64(ctx.start_time),
+		StartTimeTime:  ticksToDuration(ctx.start_time, avTimeBaseQ),
+		StartTimeText:  ticksToText(ctx.start_time, avTimeBaseQ),
+		Duration:       int64(ctx.duration),
+		DurationTime:   ticksToDuration(ctx.duration, avTimeBaseQ),
+		DurationText:   ticksToText(ctx.duration, avTimeBaseQ),
 		BitRate:        uint64(ctx.bit_rate),
 		FormatName:     C.GoString(ctx.iformat.name),
 		FormatLongName: C.GoString(ctx.iformat.long_name),
-		FileSize:       fileSize,
-		FileSizeText:   FormatBytes(fileSize),
-		FileExt:        fileExt,
+		Chapters:       newAVChapters(ctx),
+		Programs:       newAVPrograms(ctx),
+		Metadata:       dictToMap(ctx.metadata),
+		FileSizeText:   FormatBytes(0),
 	}
-
-	return formatCtx, nil
 }
@@ -2,10 +2,14 @@ package mediafileinfo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestAVRational_String(t *testing.T) {
@@ -79,6 +83,225 @@ func TestGetMediaInfo(t *testing.T) {
 	}
 }
 
+func TestGetMediaInfo_CodecParameters(t *testing.T) {
+	info, err := GetMediaInfo("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+	if len(info.Streams) != 2 {
+		t.Fatalf("got %d streams, want 2", len(info.Streams))
+	}
+
+	// FFVHUFF video, 720x576
+	video := info.Streams[0].CodecParameters
+	if video.CodecType != AVMEDIA_TYPE_VIDEO {
+		t.Fatalf("stream 0: CodecType = %v, want VIDEO", video.CodecType)
+	}
+	if want := uint32('F') | uint32('F')<<8 | uint32('V')<<16 | uint32('H')<<24; video.CodecTag != want {
+		t.Errorf("video: CodecTag = %#x, want %#x", video.CodecTag, want)
+	}
+	if video.CodecTagText != "FFVH" {
+		t.Errorf("video: CodecTagText = %q, want FFVH", video.CodecTagText)
+	}
+	if video.ExtradataSize == 0 {
+		t.Error("video: Expected ExtradataSize > 0")
+	}
+	if video.Width != 720 || video.Height != 576 {
+		t.Errorf("video: size = %dx%d, want 720x576", video.Width, video.Height)
+	}
+
+	// 32 bit float stereo audio in a WAVE_FORMAT_EXTENSIBLE header
+	audio := info.Streams[1].CodecParameters
+	if audio.CodecType != AVMEDIA_TYPE_AUDIO {
+		t.Fatalf("stream 1: CodecType = %v, want AUDIO", audio.CodecType)
+	}
+	if audio.CodecTag != 3 {
+		t.Errorf("audio: CodecTag = %#x, want 0x3", audio.CodecTag)
+	}
+	if audio.BitsPerCodedSample != 32 {
+		t.Errorf("audio: BitsPerCodedSample = %d, want 32", audio.BitsPerCodedSample)
+	}
+	if audio.BitsPerRawSample != 32 {
+		t.Errorf("audio: BitsPerRawSample = %d, want 32", audio.BitsPerRawSample)
+	}
+	if audio.BlockAlign != 8 {
+		t.Errorf("audio: BlockAlign = %d, want 8", audio.BlockAlign)
+	}
+	if audio.SampleRate != 32000 {
+		t.Errorf("audio: SampleRate = %d, want 32000", audio.SampleRate)
+	}
+	if audio.Channels != 2 {
+		t.Errorf("audio: Channels = %d, want 2", audio.Channels)
+	}
+}
+
+func TestGetMediaInfo_CodecParametersHDR(t *testing.T) {
+	// VP9 track with the Matroska Colour elements of HDR10 content
+	info, err := GetMediaInfo("testdata/hdr_rotated.mkv")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+	video := info.Streams[0].CodecParameters
+
+	if video.ColorRange != AVCOL_RANGE_MPEG || video.ColorPrimaries != AVCOL_PRI_BT2020 ||
+		video.ColorTrc != AVCOL_TRC_SMPTE2084 || video.ColorSpace != AVCOL_SPC_BT2020_NCL {
+		t.Errorf("Range/Primaries/Trc/Space = %v/%v/%v/%v, want tv/bt2020/smpte2084/bt2020nc",
+			video.ColorRange, video.ColorPrimaries, video.ColorTrc, video.ColorSpace)
+	}
+	if video.ChromaLocation != AVCHROMA_LOC_LEFT {
+		t.Errorf("ChromaLocation = %v, want left", video.ChromaLocation)
+	}
+	// content light level, mastering display and display matrix
+	if video.NbCodedSideData != 3 {
+		t.Errorf("NbCodedSideData = %d, want 3", video.NbCodedSideData)
+	}
+	// no CodecPrivate: AV_PROFILE_UNKNOWN and AV_LEVEL_UNKNOWN
+	if video.Profile != -99 || video.Level != -99 || video.ProfileText != "" {
+		t.Errorf("Profile/Level/ProfileText = %d/%d/%q, want -99/-99/empty", video.Profile, video.Level, video.ProfileText)
+	}
+	if video.VideoDelay != 0 {
+		t.Errorf("VideoDelay = %d, want 0", video.VideoDelay)
+	}
+}
+
+func TestGetMediaInfo_CodecParametersAudio(t *testing.T) {
+	// Opus header with CodecDelay 6.5ms and SeekPreRoll 80ms, MP2 with three frames
+	info, err := GetMediaInfo("testdata/audio.mkv")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+	if len(info.Streams) != 2 {
+		t.Fatalf("got %d streams, want 2", len(info.Streams))
+	}
+
+	// both are given in samples at 48 kHz
+	opus := info.Streams[0].CodecParameters
+	if opus.ExtradataSize != 19 {
+		t.Errorf("opus: ExtradataSize = %d, want 19", opus.ExtradataSize)
+	}
+	if opus.InitialPadding != 312 {
+		t.Errorf("opus: InitialPadding = %d, want 312", opus.InitialPadding)
+	}
+	if opus.SeekPreroll != 3840 {
+		t.Errorf("opus: SeekPreroll = %d, want 3840", opus.SeekPreroll)
+	}
+	if opus.TrailingPadding != 0 {
+		t.Errorf("opus: TrailingPadding = %d, want 0", opus.TrailingPadding)
+	}
+
+	mp2 := info.Streams[1].CodecParameters
+	if mp2.FrameSize != 1152 {
+		t.Errorf("mp2: FrameSize = %d, want 1152", mp2.FrameSize)
+	}
+	if mp2.SampleRate != 48000 || mp2.Channels != 2 {
+		t.Errorf("mp2: SampleRate/Channels = %d/%d, want 48000/2", mp2.SampleRate, mp2.Channels)
+	}
+	if mp2.InitialPadding != 0 || mp2.SeekPreroll != 0 {
+		t.Errorf("mp2: InitialPadding/SeekPreroll = %d/%d, want 0/0", mp2.InitialPadding, mp2.SeekPreroll)
+	}
+}
+
+func TestGetMediaInfo_StreamTiming(t *testing.T) {
+	info, err := GetMediaInfo("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+
+	if info.Duration == AV_NOPTS_VALUE || info.DurationTime <= 0 {
+		t.Errorf("Duration/DurationTime = %d/%v, want a known duration", info.Duration, info.DurationTime)
+	}
+	if want := time.Duration(info.Duration) * time.Microsecond; info.DurationTime != want {
+		t.Errorf("DurationTime = %v, want %v", info.DurationTime, want)
+	}
+	if info.DurationText == "N/A" {
+		t.Errorf("DurationText = N/A, want a duration")
+	}
+
+	video := info.Streams[0]
+	if video.StartTime != 0 || video.StartTimeTime != 0 {
+		t.Errorf("video: StartTime/StartTimeTime = %d/%v, want 0", video.StartTime, video.StartTimeTime)
+	}
+	if video.RealFrameRate != (AVRational{Num: 25, Den: 1}) {
+		t.Errorf("video: RealFrameRate = %v, want 25:1", video.RealFrameRate)
+	}
+	if video.NbFrames <= 0 {
+		t.Errorf("video: NbFrames = %d, want > 0 from the AVI header", video.NbFrames)
+	}
+	// 25 fps in a 1/25 time base: one tick per frame
+	if want := time.Duration(video.Duration) * 40 * time.Millisecond; video.DurationTime != want {
+		t.Errorf("video: DurationTime = %v, want %v for %d ticks", video.DurationTime, want, video.Duration)
+	}
+}
+
+func TestGetMediaInfoContext(t *testing.T) {
+	info, err := GetMediaInfoContext(context.Background(), "testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("GetMediaInfoContext returned error: %v", err)
+	}
+	if len(info.Streams) == 0 {
+		t.Error("Expected at least one stream in AVFormatContext")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := GetMediaInfoContext(ctx, "testdata/sample.avi"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetMediaInfoContext with canceled context = %v, want context.Canceled", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	if _, err := GetMediaInfoContext(ctx, "testdata/sample.avi"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetMediaInfoContext with expired deadline = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// blockingReader serves data but blocks in the given read until ctx is canceled,
+// so that the cancellation hits libavformat while it is probing.
+type blockingReader struct {
+	r       io.Reader
+	ctx     context.Context
+	blockAt int
+	reads   int
+	blocked chan struct{}
+}
+
+func (b *blockingReader) Read(p []byte) (int, error) {
+	b.reads++
+	if b.reads == b.blockAt {
+		close(b.blocked)
+		<-b.ctx.Done()
+	}
+	return b.r.Read(p)
+}
+
+func TestGetMediaInfoContext_CancelWhileProbing(t *testing.T) {
+	data, err := os.ReadFile("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("could not read test file: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// the AVIO buffer holds 64 KiB: the third read happens after the header is parsed
+	br := &blockingReader{r: bytes.NewReader(data), ctx: ctx, blockAt: 3, blocked: make(chan struct{})}
+	go func() {
+		<-br.blocked
+		cancel()
+	}()
+
+	before := liveInputs.Load()
+	_, err = getMediaInfo(ctx, "sample.avi", &avioSource{r: br, size: -1}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("getMediaInfo canceled while probing = %v, want context.Canceled", err)
+	}
+	if br.reads < br.blockAt {
+		t.Errorf("reader was read %d times, want the cancellation during probing", br.reads)
+	}
+	if n := liveInputs.Load(); n != before {
+		t.Errorf("%d inputs not released after cancellation", n-before)
+	}
+}
+
 func TestPrintAVContextJSON(t *testing.T) {
 	// Create a minimal AVFormatContext for testing
 	ctx := &AVFormatContext{
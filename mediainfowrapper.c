@@ -1,30 +1,161 @@
 // Copyright 2025 archeopternix. All rights reserved. MIT license.
 
 #include "mediainfowrapper.h"
+#include "_cgo_export.h"
 #include <libavformat/avformat.h>
 #include <libavutil/avutil.h>
+#include <libavutil/channel_layout.h>
+#include <stdarg.h>
+#include <stdio.h>
 #include <stdlib.h>
 #include <string.h>
 
+#define AVIO_BUFFER_SIZE 65536
+
 // Returns a pointer to AVFormatContext or NULL on error
 AVFormatContext* Get_avformat_context(const char* filename) {
     AVFormatContext* ctx = NULL;
-    if (avformat_open_input(&ctx, filename, NULL, NULL) < 0)
-        return NULL;
-    if (avformat_find_stream_info(ctx, NULL) < 0) {
-        avformat_close_input(&ctx);
+    int stage;
+    if (Open_avformat_context(&ctx, filename, NULL, NULL, &stage) < 0)
         return NULL;
-    }
     return ctx;
 }
 
+// Opens the input and reads the stream information. *ctx may point to a
+// preallocated context (e.g. with a custom AVIOContext attached), which is
+// freed on failure. format_name forces a demuxer and options are passed to
+// it, both may be NULL. Returns 0 on success or a negative AVERROR code, in
+// which case *stage tells which step failed.
+int Open_avformat_context(AVFormatContext** ctx, const char* url, const char* format_name, AVDictionary** options, int* stage) {
+    AVIOContext* pb = (*ctx && ((*ctx)->flags & AVFMT_FLAG_CUSTOM_IO)) ? (*ctx)->pb : NULL;
+    const AVInputFormat* fmt = NULL;
+    *stage = PROBE_STAGE_OPEN_INPUT;
+    if (format_name && *format_name) {
+        fmt = av_find_input_format(format_name);
+        if (!fmt) {
+            avformat_free_context(*ctx);
+            *ctx = NULL;
+            Free_avio_context(pb);
+            return AVERROR_DEMUXER_NOT_FOUND;
+        }
+    }
+    int ret = avformat_open_input(ctx, url, fmt, options);
+    if (ret < 0) {
+        Free_avio_context(pb);
+        return ret;
+    }
+    *stage = PROBE_STAGE_FIND_STREAM_INFO;
+    ret = avformat_find_stream_info(*ctx, NULL);
+    if (ret < 0) {
+        Free_avformat_context(*ctx);
+        *ctx = NULL;
+        return ret;
+    }
+    return 0;
+}
+
 // Frees the AVFormatContext
 void Free_avformat_context(AVFormatContext* ctx) {
     if (ctx) {
+        AVIOContext* pb = (ctx->flags & AVFMT_FLAG_CUSTOM_IO) ? ctx->pb : NULL;
         avformat_close_input(&ctx);
+        Free_avio_context(pb);
+    }
+}
+
+// Frees a custom AVIOContext together with its buffer
+void Free_avio_context(AVIOContext* pb) {
+    if (pb) {
+        av_freep(&pb->buffer);
+        avio_context_free(&pb);
     }
 }
 
+static int read_go_reader(void* opaque, uint8_t* buf, int buf_size) {
+    return goAVIORead((uintptr_t)opaque, buf, buf_size);
+}
+
+static int64_t seek_go_reader(void* opaque, int64_t offset, int whence) {
+    return goAVIOSeek((uintptr_t)opaque, offset, whence);
+}
+
+// Attaches a custom AVIOContext to ctx which reads from the Go reader
+// identified by handle. Returns 0 on success or a negative AVERROR code.
+int Attach_go_reader(AVFormatContext* ctx, uintptr_t handle, int seekable) {
+    unsigned char* buffer = av_malloc(AVIO_BUFFER_SIZE);
+    if (!buffer)
+        return AVERROR(ENOMEM);
+    AVIOContext* pb = avio_alloc_context(buffer, AVIO_BUFFER_SIZE, 0, (void*)handle,
+                                         read_go_reader, NULL, seekable ? seek_go_reader : NULL);
+    if (!pb) {
+        av_free(buffer);
+        return AVERROR(ENOMEM);
+    }
+    ctx->pb = pb;
+    ctx->flags |= AVFMT_FLAG_CUSTOM_IO;
+    return 0;
+}
+
+static int interrupt_cb(void* opaque) {
+    return __atomic_load_n((int*)opaque, __ATOMIC_SEQ_CST);
+}
+
+// Installs an interrupt callback which aborts blocking libavformat calls
+// as soon as *flag is set to a non-zero value.
+void Set_interrupt_flag(AVFormatContext* ctx, int* flag) {
+    ctx->interrupt_callback.callback = interrupt_cb;
+    ctx->interrupt_callback.opaque = flag;
+}
+
+// Sets *flag, may be called from any thread
+void Raise_interrupt_flag(int* flag) {
+    __atomic_store_n(flag, 1, __ATOMIC_SEQ_CST);
+}
+
+static int log_forward;                      // forward messages to the Go log handler
+static _Thread_local uintptr_t log_collector; // collector of the probe running on this thread
+
+static void log_callback(void* avcl, int level, const char* fmt, va_list vl) {
+    int forward = __atomic_load_n(&log_forward, __ATOMIC_SEQ_CST);
+    int lvl = level & 0xff;
+    int collect = log_collector && lvl <= AV_LOG_WARNING;
+
+    if (collect || (forward && lvl <= av_log_get_level())) {
+        char line[1024];
+        va_list vl2;
+        va_copy(vl2, vl);
+        vsnprintf(line, sizeof(line), fmt, vl2);
+        va_end(vl2);
+
+        const char* component = "";
+        const AVClass* avc = avcl ? *(const AVClass**)avcl : NULL;
+        if (avc && avc->item_name)
+            component = avc->item_name(avcl);
+
+        goLogMessage(collect ? log_collector : 0, forward, lvl, (char*)component, line);
+    }
+    if (!forward)
+        av_log_default_callback(avcl, level, fmt, vl);
+}
+
+// Routes all FFmpeg log messages through log_callback
+void Install_log_callback(void) {
+    av_log_set_callback(log_callback);
+}
+
+// Enables or disables forwarding of log messages to Go. If disabled,
+// messages are written to stderr by av_log_default_callback.
+void Set_log_forward(int forward) {
+    __atomic_store_n(&log_forward, forward, __ATOMIC_SEQ_CST);
+}
+
+// Sets the warning collector for the calling thread and returns the previous one
+uintptr_t Set_log_collector(uintptr_t handle) {
+    uintptr_t prev = log_collector;
+    log_collector = handle;
+    return prev;
+}
+
 // Helper: number of streams
 int Get_stream_count(AVFormatContext* ctx) {
     return ctx ? ctx->nb_streams : -1;
@@ -47,4 +178,27 @@ AVStream* Get_stream_by_index(AVFormatContext *fmt_ctx, int index) {
         return NULL;
     }
     return fmt_ctx->streams[index];
-}
\ No newline at end of file
+}
+
+// Returns a pointer to the AVChapter with the given index or NULL if the index is invalid
+AVChapter* Get_chapter_by_index(AVFormatContext *fmt_ctx, int index) {
+    if (!fmt_ctx || index < 0 || index >= fmt_ctx->nb_chapters) {
+        return NULL;
+    }
+    return fmt_ctx->chapters[index];
+}
+
+// Returns a pointer to the AVProgram with the given index or NULL if the index is invalid
+AVProgram* Get_program_by_index(AVFormatContext *fmt_ctx, int index) {
+    if (!fmt_ctx || index < 0 || index >= fmt_ctx->nb_programs) {
+        return NULL;
+    }
+    return fmt_ctx->programs[index];
+}
+
+// Returns the channel bitmask of a native or ambisonic layout, 0 otherwise
+uint64_t Get_channel_layout_mask(const AVChannelLayout* layout) {
+    if (layout->order == AV_CHANNEL_ORDER_NATIVE || layout->order == AV_CHANNEL_ORDER_AMBISONIC)
+        return layout->u.mask;
+    return 0;
+}
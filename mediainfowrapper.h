@@ -3,23 +3,59 @@
 #ifndef MEDIAINFOWRAPPER_H
 #define MEDIAINFOWRAPPER_H
 
+#include <errno.h>
+#include <stdint.h>
 #include <libavformat/avformat.h>
 
 #ifdef __cplusplus
 extern "C" {
 #endif
 
+// AVERROR codes of errno values, which cgo cannot evaluate itself
+enum {
+    AVERROR_EACCES = AVERROR(EACCES),
+    AVERROR_EAGAIN = AVERROR(EAGAIN),
+    AVERROR_EIO    = AVERROR(EIO),
+    AVERROR_ENOENT = AVERROR(ENOENT),
+    AVERROR_ENOMEM = AVERROR(ENOMEM),
+    AVERROR_EPERM  = AVERROR(EPERM),
+};
+
+// Stage of Open_avformat_context or of the packet reading which failed
+enum {
+    PROBE_STAGE_OPEN_INPUT       = 1,
+    PROBE_STAGE_FIND_STREAM_INFO = 2,
+    PROBE_STAGE_READ_FRAME       = 3,
+};
+
 AVFormatContext* Get_avformat_context(const char* filename);
+int Open_avformat_context(AVFormatContext** ctx, const char* url, const char* format_name, AVDictionary** options, int* stage);
 void Free_avformat_context(AVFormatContext* ctx);
+void Free_avio_context(AVIOContext* pb);
+
+// Custom I/O backed by a Go io.Reader
+int Attach_go_reader(AVFormatContext* ctx, uintptr_t handle, int seekable);
+
+// Cancellation via AVFormatContext.interrupt_callback
+void Set_interrupt_flag(AVFormatContext* ctx, int* flag);
+void Raise_interrupt_flag(int* flag);
+
+// Log bridge to Go
+void Install_log_callback(void);
+void Set_log_forward(int forward);
+uintptr_t Set_log_collector(uintptr_t handle);
 
 // Optionally, helper functions to retrieve fields
 int Get_stream_count(AVFormatContext* ctx);
 int64_t Get_duration(AVFormatContext* ctx);
 const char* Get_format_name(AVFormatContext* ctx);
 AVStream* Get_stream_by_index(AVFormatContext *fmt_ctx, int index);
+AVChapter* Get_chapter_by_index(AVFormatContext *fmt_ctx, int index);
+AVProgram* Get_program_by_index(AVFormatContext *fmt_ctx, int index);
+uint64_t Get_channel_layout_mask(const AVChannelLayout* layout);
 
 #ifdef __cplusplus
 }
 #endif
 
-#endif // MEDIAINFOWRAPPER_H
\ No newline at end of file
+#endif // MEDIAINFOWRAPPER_H
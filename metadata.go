@@ -0,0 +1,27 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+
+// dictToMap copies all entries of an AVDictionary, e.g. the metadata of a
+// format context or stream, into a map. Returns nil if the dictionary is empty.
+func dictToMap(dict *C.AVDictionary) map[string]string {
+	if C.av_dict_count(dict) == 0 {
+		return nil
+	}
+
+	m := make(map[string]string, int(C.av_dict_count(dict)))
+	var entry *C.AVDictionaryEntry
+	for {
+		entry = C.av_dict_iterate(dict, entry)
+		if entry == nil {
+			break
+		}
+		m[C.GoString(entry.key)] = C.GoString(entry.value)
+	}
+	return m
+}
@@ -0,0 +1,41 @@
+package mediafileinfo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGetMediaInfo_Metadata(t *testing.T) {
+	info, err := GetMediaInfo("testdata/tags.wav")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+
+	want := map[string]string{"title": "Test Title", "artist": "Test Artist"}
+	for key, value := range want {
+		if got := info.Metadata[key]; got != value {
+			t.Errorf("Metadata[%q] = %q, want %q", key, got, value)
+		}
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"Metadata":{`) {
+		t.Errorf("Expected Metadata in JSON: %s", data)
+	}
+}
+
+func TestGetMediaInfo_NoMetadata(t *testing.T) {
+	info, err := GetMediaInfo("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+	for _, s := range info.Streams {
+		if s.Metadata != nil && len(s.Metadata) == 0 {
+			t.Errorf("stream %d: Expected nil instead of an empty Metadata map", s.Index)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// ProbeOptions controls how libavformat detects the format and streams of an input.
+// Zero values keep FFmpeg's defaults, a nil *ProbeOptions is the same as the zero value.
+type ProbeOptions struct {
+	ProbeSize       int64             // Maximum number of bytes read to detect the format (probesize).
+	AnalyzeDuration time.Duration     // Maximum duration of data analyzed to find the streams (analyzeduration).
+	FPSProbeSize    int               // Number of frames used to determine the frame rate (fpsprobesize).
+	InputFormat     string            // Short name of a demuxer to force, e.g. "h264" or "s16le".
+	DemuxerOptions  map[string]string // Further demuxer options, e.g. "sample_rate" and "channels" for raw audio.
+	CollectWarnings bool              // Collect FFmpeg's warnings and errors into AVFormatContext.Warnings.
+	AnalyzeGOP      bool              // Read all packets to report the keyframes and GOP structure of video streams in AVStream.GOP.
+	Accurate        bool              // Read all packets to count the frames and measure the real start and duration, see Measurement.
+}
+
+// dictionary builds the AVDictionary passed to avformat_open_input. The caller
+// must release the result with av_dict_free; nil is returned if there are no options.
+func (o *ProbeOptions) dictionary() *C.AVDictionary {
+	var dict *C.AVDictionary
+	if o == nil {
+		return dict
+	}
+
+	set := func(key, value string) {
+		ckey := C.CString(key)
+		defer C.free(unsafe.Pointer(ckey))
+		cvalue := C.CString(value)
+		defer C.free(unsafe.Pointer(cvalue))
+		C.av_dict_set(&dict, ckey, cvalue, 0)
+	}
+
+	if o.ProbeSize > 0 {
+		set("probesize", strconv.FormatInt(o.ProbeSize, 10))
+	}
+	if o.AnalyzeDuration > 0 {
+		set("analyzeduration", strconv.FormatInt(o.AnalyzeDuration.Microseconds(), 10))
+	}
+	if o.FPSProbeSize > 0 {
+		set("fpsprobesize", strconv.Itoa(o.FPSProbeSize))
+	}
+	for key, value := range o.DemuxerOptions {
+		set(key, value)
+	}
+	return dict
+}
+
+// inputFormat returns the forced demuxer name or an empty string.
+func (o *ProbeOptions) inputFormat() string {
+	if o == nil {
+		return ""
+	}
+	return o.InputFormat
+}
+
+// collectWarnings reports whether FFmpeg's log messages are collected.
+func (o *ProbeOptions) collectWarnings() bool {
+	return o != nil && o.CollectWarnings
+}
+
+// scanPackets reports whether a packet based analysis has to read the whole input.
+func (o *ProbeOptions) scanPackets() bool {
+	return o != nil && (o.AnalyzeGOP || o.Accurate)
+}
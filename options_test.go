@@ -0,0 +1,59 @@
+package mediafileinfo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetMediaInfoWithOptions(t *testing.T) {
+	opts := &ProbeOptions{
+		ProbeSize:       10 << 20,
+		AnalyzeDuration: 10 * time.Second,
+		FPSProbeSize:    10,
+		InputFormat:     "avi",
+	}
+
+	info, err := GetMediaInfoWithOptions("testdata/sample.avi", opts)
+	if err != nil {
+		t.Fatalf("GetMediaInfoWithOptions returned error: %v", err)
+	}
+	if info.FormatName != "avi" {
+		t.Errorf("FormatName = %q, want %q", info.FormatName, "avi")
+	}
+
+	if _, err := GetMediaInfoWithOptions("testdata/sample.avi", nil); err != nil {
+		t.Errorf("GetMediaInfoWithOptions with nil options returned error: %v", err)
+	}
+}
+
+func TestGetMediaInfoWithOptions_RawAudio(t *testing.T) {
+	opts := &ProbeOptions{
+		InputFormat:    "s16le",
+		DemuxerOptions: map[string]string{"sample_rate": "48000", "channels": "1"},
+	}
+
+	info, err := GetMediaInfoWithOptions("testdata/sample.avi", opts)
+	if err != nil {
+		t.Fatalf("GetMediaInfoWithOptions returned error: %v", err)
+	}
+	if len(info.Streams) != 1 {
+		t.Fatalf("got %d streams, want 1", len(info.Streams))
+	}
+	par := info.Streams[0].CodecParameters
+	if par.CodecType != AVMEDIA_TYPE_AUDIO {
+		t.Errorf("CodecType = %v, want %v", par.CodecType, AVMEDIA_TYPE_AUDIO)
+	}
+	if par.SampleRate != 48000 {
+		t.Errorf("SampleRate = %d, want 48000", par.SampleRate)
+	}
+	if par.Channels != 1 {
+		t.Errorf("Channels = %d, want 1", par.Channels)
+	}
+}
+
+func TestGetMediaInfoWithOptions_UnknownFormat(t *testing.T) {
+	_, err := GetMediaInfoWithOptions("testdata/sample.avi", &ProbeOptions{InputFormat: "no-such-format"})
+	if err == nil {
+		t.Error("Expected an error for an unknown input format")
+	}
+}
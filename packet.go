@@ -0,0 +1,153 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Packet describes one demuxed packet of a stream without its payload, mirroring
+// the fields of FFmpeg's AVPacket. Timestamps are in units of the stream time base;
+// AV_NOPTS_VALUE marks an unknown timestamp, the *Time fields are 0 then.
+// See: https://ffmpeg.org/doxygen/trunk/structAVPacket.html
+type Packet struct {
+	StreamIndex  int           // Index of the stream the packet belongs to.
+	PTS          int64         // Presentation timestamp in stream time_base units.
+	PTSTime      time.Duration // Presentation timestamp as time.
+	DTS          int64         // Decompression timestamp in stream time_base units.
+	DTSTime      time.Duration // Decompression timestamp as time.
+	Duration     int64         // Duration in stream time_base units, 0 if unknown.
+	DurationTime time.Duration // Duration as time.
+	Size         int           // Size of the payload in bytes.
+	Pos          int64         // Byte position in the input, -1 if unknown.
+	Key          bool          // The packet contains a keyframe (AV_PKT_FLAG_KEY).
+	Corrupt      bool          // The demuxer flagged the packet as corrupt (AV_PKT_FLAG_CORRUPT).
+	Discard      bool          // The packet is required for decoding only and has to be dropped after (AV_PKT_FLAG_DISCARD).
+}
+
+// PacketReader walks the packets of a media file in file order with av_read_frame
+// without decoding them. A PacketReader is not safe for concurrent use.
+type PacketReader struct {
+	ctx      context.Context
+	in       *inputContext
+	pkt      *C.AVPacket
+	filename string
+}
+
+// OpenPacketReader opens filename for reading its packets. Canceling ctx aborts
+// blocking reads, Next returns ctx.Err() then. The reader must be closed.
+func OpenPacketReader(ctx context.Context, filename string) (*PacketReader, error) {
+	in, err := openInput(ctx, filename, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	pkt := C.av_packet_alloc()
+	if pkt == nil {
+		in.close()
+		return nil, fmt.Errorf("could not allocate packet for %s", filename)
+	}
+	return &PacketReader{ctx: ctx, in: in, pkt: pkt, filename: filename}, nil
+}
+
+// ReadPackets calls fn for every packet of filename in file order. Reading stops
+// at the first error returned by fn, which is returned by ReadPackets.
+func ReadPackets(filename string, fn func(Packet) error) error {
+	r, err := OpenPacketReader(context.Background(), filename)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for {
+		p, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+}
+
+// Next returns the next packet. At the end of the file it returns io.EOF, read
+// errors are reported as *ProbeError with Stage StageReadFrame.
+func (r *PacketReader) Next() (Packet, error) {
+	if err := r.read(); err != nil {
+		return Packet{}, err
+	}
+	return r.packet(), nil
+}
+
+// TimeBase returns the time base of the timestamps of the given stream.
+func (r *PacketReader) TimeBase(streamIndex int) AVRational {
+	if r.in == nil || streamIndex < 0 || streamIndex >= int(C.Get_stream_count(r.in.fc)) {
+		return AVRational{}
+	}
+	return newAVRational(C.Get_stream_by_index(r.in.fc, C.int(streamIndex)).time_base)
+}
+
+// Close releases the packet and the format context. Calling Close more than once is a no-op.
+func (r *PacketReader) Close() error {
+	if r.in == nil {
+		return nil
+	}
+	C.av_packet_free(&r.pkt)
+	r.in.close()
+	r.in = nil
+	return nil
+}
+
+// read replaces the current packet by the next one of the file.
+func (r *PacketReader) read() error {
+	if r.in == nil {
+		return fmt.Errorf("packet reader for %s is closed", r.filename)
+	}
+	C.av_packet_unref(r.pkt)
+	ret := C.av_read_frame(r.in.fc, r.pkt)
+	switch {
+	case ret == C.AVERROR_EOF:
+		// libavformat may report a failing custom reader as end of file
+		if err := r.in.sourceErr(); err != nil {
+			return newProbeError(r.filename, C.PROBE_STAGE_READ_FRAME, ret, err)
+		}
+		return io.EOF
+	case ret < 0:
+		if err := r.ctx.Err(); err != nil {
+			return err
+		}
+		return newProbeError(r.filename, C.PROBE_STAGE_READ_FRAME, ret, r.in.sourceErr())
+	}
+	return nil
+}
+
+// packet maps the current C packet.
+func (r *PacketReader) packet() Packet {
+	pkt := r.pkt
+	tb := C.Get_stream_by_index(r.in.fc, pkt.stream_index).time_base
+	flags := pkt.flags
+	return Packet{
+		StreamIndex:  int(pkt.stream_index),
+		PTS:          int64(pkt.pts),
+		PTSTime:      ticksToDuration(pkt.pts, tb),
+		DTS:          int64(pkt.dts),
+		DTSTime:      ticksToDuration(pkt.dts, tb),
+		Duration:     int64(pkt.duration),
+		DurationTime: ticksToDuration(pkt.duration, tb),
+		Size:         int(pkt.size),
+		Pos:          int64(pkt.pos),
+		Key:          flags&C.AV_PKT_FLAG_KEY != 0,
+		Corrupt:      flags&C.AV_PKT_FLAG_CORRUPT != 0,
+		Discard:      flags&C.AV_PKT_FLAG_DISCARD != 0,
+	}
+}
@@ -0,0 +1,83 @@
+package mediafileinfo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestReadPackets(t *testing.T) {
+	counts := make(map[int]int)
+	lastPTS := make(map[int]int64)
+	err := ReadPackets("testdata/sample.avi", func(p Packet) error {
+		counts[p.StreamIndex]++
+		if p.Size <= 0 {
+			t.Errorf("stream %d: Size = %d, want > 0", p.StreamIndex, p.Size)
+		}
+		if p.StreamIndex == 0 {
+			// FFVHUFF is intra only, every packet is a keyframe
+			if !p.Key {
+				t.Errorf("video packet at %v: Expected keyframe", p.PTSTime)
+			}
+			if last, ok := lastPTS[0]; ok && p.PTS <= last {
+				t.Errorf("video: PTS %d not after %d", p.PTS, last)
+			}
+			lastPTS[0] = p.PTS
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadPackets returned error: %v", err)
+	}
+	if counts[0] == 0 || counts[1] == 0 {
+		t.Errorf("packet counts = %v, want packets for both streams", counts)
+	}
+}
+
+func TestReadPackets_StopsOnError(t *testing.T) {
+	stop := errors.New("stop")
+	n := 0
+	err := ReadPackets("testdata/sample.avi", func(p Packet) error {
+		n++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("ReadPackets returned %v, want %v", err, stop)
+	}
+	if n != 1 {
+		t.Errorf("fn called %d times, want 1", n)
+	}
+}
+
+func TestPacketReader(t *testing.T) {
+	r, err := OpenPacketReader(context.Background(), "testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("OpenPacketReader returned error: %v", err)
+	}
+	defer r.Close()
+
+	if tb := r.TimeBase(0); tb != (AVRational{Num: 1, Den: 25}) {
+		t.Errorf("TimeBase(0) = %v, want 1:25", tb)
+	}
+
+	p, err := r.Next()
+	if err != nil {
+		t.Fatalf("Next returned error: %v", err)
+	}
+	if want := r.TimeBase(p.StreamIndex).ToDuration(p.PTS); p.PTSTime != want {
+		t.Errorf("PTSTime = %v, want %v", p.PTSTime, want)
+	}
+
+	for err == nil {
+		_, err = r.Next()
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("Next returned %v at the end, want io.EOF", err)
+	}
+
+	r.Close()
+	if _, err := r.Next(); err == nil {
+		t.Errorf("Expected error from Next after Close")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+#include <libavutil/pixdesc.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"strconv"
+	"unsafe"
+)
+
+// PixelFormatDescriptor describes the memory layout of a pixel format, mirroring FFmpeg's AVPixFmtDescriptor.
+// See: https://ffmpeg.org/doxygen/trunk/structAVPixFmtDescriptor.html
+type PixelFormatDescriptor struct {
+	Format            AVPixelFormat // The described pixel format.
+	Name              string        // Name of the pixel format, e.g. yuv420p10le.
+	Components        int           // Number of components per pixel (1-4).
+	BitDepth          []int         // Number of bits of each component.
+	BitsPerPixel      int           // Number of bits per pixel, ignoring padding.
+	Log2ChromaW       int           // Horizontal chroma subsampling as shift, 1 for 4:2:x.
+	Log2ChromaH       int           // Vertical chroma subsampling as shift, 1 for 4:2:0.
+	ChromaSubsampling string        `json:",omitempty"` // Chroma subsampling of YUV formats, e.g. "4:2:0" or "4:2:2".
+	Planar            bool          // At least one component is stored in a separate plane.
+	Alpha             bool          // The format has an alpha channel.
+	RGB               bool          // The format is RGB-like instead of YUV or gray.
+	BigEndian         bool          // Components are stored big endian.
+	Palette           bool          // The format uses a palette.
+	Float             bool          // Components are floating point values.
+	HWAccel           bool          // The format is an opaque hardware surface.
+}
+
+// PixelFormatByName returns the pixel format with the given name (e.g. "yuv420p")
+// or AV_PIX_FMT_NONE if there is none.
+func PixelFormatByName(name string) AVPixelFormat {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return AVPixelFormat(C.av_get_pix_fmt(cname))
+}
+
+// String returns the FFmpeg name of the pixel format, e.g. yuv420p or p010le.
+func (p AVPixelFormat) String() string {
+	if p == AV_PIX_FMT_NONE {
+		return "none"
+	}
+	name := C.av_get_pix_fmt_name(C.enum_AVPixelFormat(p))
+	if name == nil {
+		return "AVPixelFormat(" + strconv.Itoa(int(p)) + ")"
+	}
+	return C.GoString(name)
+}
+
+// MaxBitDepth returns the largest bit depth of all components, e.g. 10 for yuv420p10le.
+func (d *PixelFormatDescriptor) MaxBitDepth() int {
+	depth := 0
+	for _, b := range d.BitDepth {
+		depth = max(depth, b)
+	}
+	return depth
+}
+
+// Descriptor returns the layout details of the pixel format or nil if it is unknown.
+func (p AVPixelFormat) Descriptor() *PixelFormatDescriptor {
+	desc := C.av_pix_fmt_desc_get(C.enum_AVPixelFormat(p))
+	if desc == nil {
+		return nil
+	}
+
+	flags := uint64(desc.flags)
+	d := &PixelFormatDescriptor{
+		Format:       p,
+		Name:         C.GoString(desc.name),
+		Components:   int(desc.nb_components),
+		BitsPerPixel: int(C.av_get_bits_per_pixel(desc)),
+		Log2ChromaW:  int(desc.log2_chroma_w),
+		Log2ChromaH:  int(desc.log2_chroma_h),
+		Planar:       flags&C.AV_PIX_FMT_FLAG_PLANAR != 0,
+		Alpha:        flags&C.AV_PIX_FMT_FLAG_ALPHA != 0,
+		RGB:          flags&C.AV_PIX_FMT_FLAG_RGB != 0,
+		BigEndian:    flags&C.AV_PIX_FMT_FLAG_BE != 0,
+		Palette:      flags&C.AV_PIX_FMT_FLAG_PAL != 0,
+		Float:        flags&C.AV_PIX_FMT_FLAG_FLOAT != 0,
+		HWAccel:      flags&C.AV_PIX_FMT_FLAG_HWACCEL != 0,
+	}
+	for i := range d.Components {
+		d.BitDepth = append(d.BitDepth, int(desc.comp[i].depth))
+	}
+	if !d.RGB && !d.HWAccel && d.Components >= 3 {
+		d.ChromaSubsampling = chromaSubsampling(d.Log2ChromaW, d.Log2ChromaH)
+	}
+	return d
+}
+
+// chromaSubsampling returns the J:a:b notation for the chroma shifts of a YUV format.
+func chromaSubsampling(log2w, log2h int) string {
+	switch {
+	case log2w == 0 && log2h == 0:
+		return "4:4:4"
+	case log2w == 0 && log2h == 1:
+		return "4:4:0"
+	case log2w == 1 && log2h == 0:
+		return "4:2:2"
+	case log2w == 1 && log2h == 1:
+		return "4:2:0"
+	case log2w == 2 && log2h == 0:
+		return "4:1:1"
+	case log2w == 2 && log2h == 2:
+		return "4:1:0"
+	}
+	return ""
+}
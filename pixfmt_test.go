@@ -0,0 +1,55 @@
+package mediafileinfo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAVPixelFormat_Descriptor(t *testing.T) {
+	tests := []struct {
+		name       string
+		depth      []int
+		subsampl   string
+		planar     bool
+		alpha, rgb bool
+	}{
+		{"yuv420p", []int{8, 8, 8}, "4:2:0", true, false, false},
+		{"yuv422p10le", []int{10, 10, 10}, "4:2:2", true, false, false},
+		{"rgba", []int{8, 8, 8, 8}, "", false, true, true},
+		{"gray", []int{8}, "", false, false, false},
+	}
+
+	for _, tt := range tests {
+		pf := PixelFormatByName(tt.name)
+		if pf == AV_PIX_FMT_NONE {
+			t.Fatalf("PixelFormatByName(%q) = none", tt.name)
+		}
+		if pf.String() != tt.name {
+			t.Errorf("String() = %q, want %q", pf.String(), tt.name)
+		}
+
+		d := pf.Descriptor()
+		if d == nil {
+			t.Fatalf("%s: Expected a descriptor", tt.name)
+		}
+		if !slices.Equal(d.BitDepth, tt.depth) {
+			t.Errorf("%s: BitDepth = %v, want %v", tt.name, d.BitDepth, tt.depth)
+		}
+		if d.ChromaSubsampling != tt.subsampl {
+			t.Errorf("%s: ChromaSubsampling = %q, want %q", tt.name, d.ChromaSubsampling, tt.subsampl)
+		}
+		if d.Planar != tt.planar || d.Alpha != tt.alpha || d.RGB != tt.rgb {
+			t.Errorf("%s: Planar/Alpha/RGB = %v/%v/%v, want %v/%v/%v", tt.name, d.Planar, d.Alpha, d.RGB, tt.planar, tt.alpha, tt.rgb)
+		}
+	}
+
+	if AV_PIX_FMT_YUV420P.String() != "yuv420p" {
+		t.Errorf("AV_PIX_FMT_YUV420P.String() = %q", AV_PIX_FMT_YUV420P.String())
+	}
+	if AV_PIX_FMT_NONE.String() != "none" || AV_PIX_FMT_NONE.Descriptor() != nil {
+		t.Errorf("Expected AV_PIX_FMT_NONE to be \"none\" without descriptor")
+	}
+	if PixelFormatByName("no-such-format") != AV_PIX_FMT_NONE {
+		t.Errorf("Expected AV_PIX_FMT_NONE for an unknown name")
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import "unsafe"
+
+// AVProgram represents a program of a multi-program stream such as an MPEG-TS broadcast capture, mirroring FFmpeg's AVProgram.
+// See: https://ffmpeg.org/doxygen/trunk/structAVProgram.html
+type AVProgram struct {
+	ID              int               // Program ID, the service ID in MPEG-TS.
+	ProgramNum      int               // Program number from the PAT.
+	PMTPID          int               // PID of the program map table.
+	PCRPID          int               // PID carrying the program clock reference.
+	PMTVersion      int               // Version of the program map table.
+	StreamIndexes   []int             // Indexes of the streams in AVFormatContext.Streams belonging to this program.
+	ServiceName     string            `json:",omitempty"` // Service name from the SDT.
+	ServiceProvider string            `json:",omitempty"` // Service provider from the SDT.
+	Metadata        map[string]string `json:",omitempty"` // All metadata tags of the program.
+}
+
+// newAVPrograms maps the programs of an opened C AVFormatContext.
+func newAVPrograms(ctx *C.AVFormatContext) []AVProgram {
+	var programs []AVProgram
+	for i := range C.int(ctx.nb_programs) {
+		p := C.Get_program_by_index(ctx, i)
+
+		var indexes []int
+		if p.nb_stream_indexes > 0 {
+			for _, idx := range unsafe.Slice(p.stream_index, int(p.nb_stream_indexes)) {
+				indexes = append(indexes, int(idx))
+			}
+		}
+
+		metadata := dictToMap(p.metadata)
+		programs = append(programs, AVProgram{
+			ID:              int(p.id),
+			ProgramNum:      int(p.program_num),
+			PMTPID:          int(p.pmt_pid),
+			PCRPID:          int(p.pcr_pid),
+			PMTVersion:      int(p.pmt_version),
+			StreamIndexes:   indexes,
+			ServiceName:     metadata["service_name"],
+			ServiceProvider: metadata["service_provider"],
+			Metadata:        metadata,
+		})
+	}
+	return programs
+}
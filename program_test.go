@@ -0,0 +1,36 @@
+package mediafileinfo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGetMediaInfo_Programs(t *testing.T) {
+	info, err := GetMediaInfo("testdata/programs.ts")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+	if len(info.Programs) != 1 {
+		t.Fatalf("got %d programs, want 1", len(info.Programs))
+	}
+
+	p := info.Programs[0]
+	if p.ProgramNum != 1 {
+		t.Errorf("ProgramNum = %d, want 1", p.ProgramNum)
+	}
+	if p.PMTPID != 0x1000 {
+		t.Errorf("PMTPID = %#x, want 0x1000", p.PMTPID)
+	}
+	if p.PCRPID != 0x100 {
+		t.Errorf("PCRPID = %#x, want 0x100", p.PCRPID)
+	}
+	if !slices.Equal(p.StreamIndexes, []int{0, 1}) {
+		t.Errorf("StreamIndexes = %v, want [0 1]", p.StreamIndexes)
+	}
+	if p.ServiceName != "Test Service" {
+		t.Errorf("ServiceName = %q, want %q", p.ServiceName, "Test Service")
+	}
+	if p.ServiceProvider != "Test Provider" {
+		t.Errorf("ServiceProvider = %q, want %q", p.ServiceProvider, "Test Provider")
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Float64 returns the value of the rational as float64 (av_q2d). A zero
+// denominator results in ±Inf or NaN.
+func (a AVRational) Float64() float64 {
+	return float64(a.Num) / float64(a.Den)
+}
+
+// Reduce returns the rational in lowest terms with a positive denominator,
+// e.g. 50:2 becomes 25:1.
+func (a AVRational) Reduce() AVRational {
+	var num, den C.int
+	C.av_reduce(&num, &den, C.int64_t(a.Num), C.int64_t(a.Den), math.MaxInt32)
+	return AVRational{Num: int(num), Den: int(den)}
+}
+
+// Mul returns a*b in lowest terms (av_mul_q).
+func (a AVRational) Mul(b AVRational) AVRational {
+	return newAVRational(C.av_mul_q(a.c(), b.c()))
+}
+
+// Div returns a/b in lowest terms (av_div_q).
+func (a AVRational) Div(b AVRational) AVRational {
+	return newAVRational(C.av_div_q(a.c(), b.c()))
+}
+
+// Inv returns 1/a, e.g. the frame duration 1:25 for the frame rate 25:1.
+func (a AVRational) Inv() AVRational {
+	return AVRational{Num: a.Den, Den: a.Num}
+}
+
+// Compare returns -1 if a < b, 0 if a == b and 1 if a > b (av_cmp_q).
+// Rationals with a zero denominator compare as ±infinity; 0:0 equals nothing
+// and is reported as 0.
+func (a AVRational) Compare(b AVRational) int {
+	switch c := C.av_cmp_q(a.c(), b.c()); {
+	case c < 0 && c != math.MinInt32:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero reports whether the rational is 0, i.e. 0:n with n != 0.
+func (a AVRational) IsZero() bool {
+	return a.Num == 0 && a.Den != 0
+}
+
+// IsValid reports whether the rational has a non-zero denominator. FFmpeg uses
+// 0:0 or 0:1 for unknown frame rates and aspect ratios.
+func (a AVRational) IsValid() bool {
+	return a.Den != 0
+}
+
+// Rescale converts ticks in units of the time base a to the time base to,
+// rounding to the nearest value like av_rescale_q does.
+func (a AVRational) Rescale(ticks int64, to AVRational) int64 {
+	return int64(C.av_rescale_q(C.int64_t(ticks), a.c(), to.c()))
+}
+
+// ToDuration converts ticks in units of the time base a to a time.Duration.
+// Returns 0 for AV_NOPTS_VALUE or an invalid time base.
+func (a AVRational) ToDuration(ticks int64) time.Duration {
+	return ticksToDuration(C.int64_t(ticks), a.c())
+}
+
+// FrameRateString formats the rational as frame rate with up to three decimals
+// and without trailing zeros, e.g. 24000:1001 as "23.976", 30000:1001 as "29.97"
+// and 25:1 as "25". Returns "N/A" for an unknown rate.
+func (a AVRational) FrameRateString() string {
+	if a.Num == 0 || a.Den == 0 {
+		return "N/A"
+	}
+	s := strconv.FormatFloat(a.Float64(), 'f', 3, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// MarshalText encodes the rational as "num/den", e.g. "30000/1001".
+func (a AVRational) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(a.Num) + "/" + strconv.Itoa(a.Den)), nil
+}
+
+// UnmarshalText decodes "num/den", "num:den" or a plain integer, e.g. "25", which is read as 25/1.
+func (a *AVRational) UnmarshalText(text []byte) error {
+	s := string(text)
+	num, den, found := strings.Cut(s, "/")
+	if !found {
+		num, den, found = strings.Cut(s, ":")
+	}
+	if !found {
+		den = "1"
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(num))
+	if err != nil {
+		return fmt.Errorf("invalid AVRational %q", s)
+	}
+	d, err := strconv.Atoi(strings.TrimSpace(den))
+	if err != nil {
+		return fmt.Errorf("invalid AVRational %q", s)
+	}
+	*a = AVRational{Num: n, Den: d}
+	return nil
+}
+
+// c converts the rational to a C AVRational.
+func (a AVRational) c() C.AVRational {
+	return C.AVRational{num: C.int(a.Num), den: C.int(a.Den)}
+}
+
+// newAVRational converts a C AVRational.
+func newAVRational(r C.AVRational) AVRational {
+	return AVRational{Num: int(r.num), Den: int(r.den)}
+}
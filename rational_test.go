@@ -0,0 +1,114 @@
+package mediafileinfo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAVRational_Arithmetic(t *testing.T) {
+	r := func(num, den int) AVRational { return AVRational{Num: num, Den: den} }
+
+	if got := r(50, 2).Reduce(); got != r(25, 1) {
+		t.Errorf("Reduce() = %v, want 25:1", got)
+	}
+	if got := r(2, 3).Mul(r(3, 4)); got != r(1, 2) {
+		t.Errorf("Mul() = %v, want 1:2", got)
+	}
+	if got := r(1, 2).Div(r(1, 4)); got != r(2, 1) {
+		t.Errorf("Div() = %v, want 2:1", got)
+	}
+	if got := r(25, 1).Inv(); got != r(1, 25) {
+		t.Errorf("Inv() = %v, want 1:25", got)
+	}
+	if got := r(1, 2).Float64(); got != 0.5 {
+		t.Errorf("Float64() = %v, want 0.5", got)
+	}
+
+	compare := []struct {
+		a, b AVRational
+		want int
+	}{
+		{r(1, 2), r(2, 4), 0},
+		{r(1, 3), r(1, 2), -1},
+		{r(30000, 1001), r(30, 1), -1},
+		{r(25, 1), r(24000, 1001), 1},
+	}
+	for _, tt := range compare {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%v.Compare(%v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	if !r(0, 1).IsZero() || r(0, 0).IsZero() || r(1, 1).IsZero() {
+		t.Errorf("IsZero() mismatch")
+	}
+	if r(0, 0).IsValid() || !r(0, 1).IsValid() {
+		t.Errorf("IsValid() mismatch")
+	}
+}
+
+func TestAVRational_Rescale(t *testing.T) {
+	tb := AVRational{Num: 1, Den: 90000}
+	if got := tb.Rescale(180000, AVRational{Num: 1, Den: 1000}); got != 2000 {
+		t.Errorf("Rescale() = %d, want 2000", got)
+	}
+	if got := tb.ToDuration(135000); got != 1500*time.Millisecond {
+		t.Errorf("ToDuration() = %v, want 1.5s", got)
+	}
+	if got := tb.ToDuration(AV_NOPTS_VALUE); got != 0 {
+		t.Errorf("ToDuration(AV_NOPTS_VALUE) = %v, want 0", got)
+	}
+}
+
+func TestAVRational_FrameRateString(t *testing.T) {
+	tests := []struct {
+		r    AVRational
+		want string
+	}{
+		{AVRational{Num: 24000, Den: 1001}, "23.976"},
+		{AVRational{Num: 30000, Den: 1001}, "29.97"},
+		{AVRational{Num: 25, Den: 1}, "25"},
+		{AVRational{Num: 50, Den: 2}, "25"},
+		{AVRational{Num: 0, Den: 0}, "N/A"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.r.FrameRateString(); got != tt.want {
+			t.Errorf("%v.FrameRateString() = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestAVRational_Text(t *testing.T) {
+	data, err := json.Marshal(AVRational{Num: 30000, Den: 1001})
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if string(data) != `"30000/1001"` {
+		t.Errorf("json.Marshal = %s, want \"30000/1001\"", data)
+	}
+
+	tests := []struct {
+		in   string
+		want AVRational
+	}{
+		{"30000/1001", AVRational{Num: 30000, Den: 1001}},
+		{"16:9", AVRational{Num: 16, Den: 9}},
+		{"25", AVRational{Num: 25, Den: 1}},
+	}
+	for _, tt := range tests {
+		var got AVRational
+		if err := got.UnmarshalText([]byte(tt.in)); err != nil {
+			t.Fatalf("UnmarshalText(%q) returned error: %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("UnmarshalText(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	var r AVRational
+	if err := r.UnmarshalText([]byte("a/b")); err == nil {
+		t.Errorf("Expected error for invalid input")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/cgo"
+	"unsafe"
+)
+
+// avioSource is the Go side of a custom AVIOContext. libavformat calls back
+// into goAVIORead and goAVIOSeek with a cgo.Handle pointing to it.
+type avioSource struct {
+	r      io.Reader
+	seeker io.Seeker // nil if the input is not seekable
+	size   int64     // total size in bytes, -1 if unknown
+	err    error     // first read or seek error other than io.EOF
+}
+
+// maxEmptyReads is the number of consecutive reads returning neither data nor
+// an error after which goAVIORead gives up, like bufio does.
+const maxEmptyReads = 100
+
+//export goAVIORead
+func goAVIORead(handle C.uintptr_t, buf *C.uint8_t, size C.int) C.int {
+	src := cgo.Handle(handle).Value().(*avioSource)
+	p := unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(size))
+
+	for range maxEmptyReads {
+		n, err := src.r.Read(p)
+		if n > 0 {
+			if err != nil && !errors.Is(err, io.EOF) {
+				src.err = err
+			}
+			return C.int(n)
+		}
+		switch {
+		case errors.Is(err, io.EOF):
+			return C.AVERROR_EOF
+		case err != nil:
+			src.err = err
+			return C.AVERROR_EIO
+		}
+	}
+	src.err = io.ErrNoProgress
+	return C.AVERROR_EIO
+}
+
+//export goAVIOSeek
+func goAVIOSeek(handle C.uintptr_t, offset C.int64_t, whence C.int) C.int64_t {
+	src := cgo.Handle(handle).Value().(*avioSource)
+	if src.seeker == nil {
+		return C.AVERROR_EIO
+	}
+
+	whence &^= C.AVSEEK_FORCE
+	if whence&C.AVSEEK_SIZE != 0 {
+		if src.size < 0 {
+			return C.AVERROR_EIO
+		}
+		return C.int64_t(src.size)
+	}
+
+	pos, err := src.seeker.Seek(int64(offset), int(whence))
+	if err != nil {
+		src.err = err
+		return C.AVERROR_EIO
+	}
+	return C.int64_t(pos)
+}
+
+// GetMediaInfoFromReader reads media data from a non-seekable stream such as an
+// HTTP body and returns its AVFormatContext. The name is used as Filename and as
+// a hint for the format detection. Formats that keep their index at the end of
+// the file may report less information than from a seekable source.
+func GetMediaInfoFromReader(r io.Reader, name string) (*AVFormatContext, error) {
+	return getMediaInfo(context.Background(), name, &avioSource{r: r, size: -1}, nil)
+}
+
+// GetMediaInfoFromReadSeeker reads media data from a seekable source such as a
+// bytes.Reader or an open file and returns its AVFormatContext. The name is used
+// as Filename and as a hint for the format detection.
+func GetMediaInfoFromReadSeeker(r io.ReadSeeker, name string) (*AVFormatContext, error) {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine size of %s: %w", name, err)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("could not rewind %s: %w", name, err)
+	}
+	return getMediaInfo(context.Background(), name, &avioSource{r: r, seeker: r, size: size}, nil)
+}
@@ -0,0 +1,92 @@
+package mediafileinfo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+// onlyReader hides the Seek method of the wrapped reader.
+type onlyReader struct {
+	r io.Reader
+}
+
+func (o onlyReader) Read(p []byte) (int, error) { return o.r.Read(p) }
+
+func TestGetMediaInfoFromReadSeeker(t *testing.T) {
+	data, err := os.ReadFile("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	info, err := GetMediaInfoFromReadSeeker(bytes.NewReader(data), "sample.avi")
+	if err != nil {
+		t.Fatalf("GetMediaInfoFromReadSeeker returned error: %v", err)
+	}
+	if info.FormatLongName != "AVI (Audio Video Interleaved)" {
+		t.Errorf("Expected FormatLongName to be 'AVI (Audio Video Interleaved)', got '%s'", info.FormatLongName)
+	}
+	if info.FileSize != int64(len(data)) {
+		t.Errorf("FileSize = %d, want %d", info.FileSize, len(data))
+	}
+	if info.FileExt != ".avi" {
+		t.Errorf("FileExt = %q, want %q", info.FileExt, ".avi")
+	}
+
+	fromFile, err := GetMediaInfo("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+	if len(info.Streams) != len(fromFile.Streams) {
+		t.Errorf("got %d streams from reader, %d from file", len(info.Streams), len(fromFile.Streams))
+	}
+}
+
+func TestGetMediaInfoFromReader(t *testing.T) {
+	f, err := os.Open("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer f.Close()
+
+	info, err := GetMediaInfoFromReader(onlyReader{f}, "sample.avi")
+	if err != nil {
+		t.Fatalf("GetMediaInfoFromReader returned error: %v", err)
+	}
+	if len(info.Streams) == 0 {
+		t.Error("Expected at least one stream in AVFormatContext")
+	}
+	if info.FileSize != 0 {
+		t.Errorf("FileSize = %d, want 0 for a non-seekable reader", info.FileSize)
+	}
+}
+
+// emptyReader returns neither data nor an error, which io.Reader allows.
+type emptyReader struct {
+	reads int
+}
+
+func (e *emptyReader) Read(p []byte) (int, error) {
+	e.reads++
+	return 0, nil
+}
+
+func TestGetMediaInfoFromReader_NoProgress(t *testing.T) {
+	r := &emptyReader{}
+	_, err := GetMediaInfoFromReader(r, "empty")
+	if !errors.Is(err, io.ErrNoProgress) {
+		t.Errorf("GetMediaInfoFromReader = %v, want io.ErrNoProgress", err)
+	}
+	if r.reads < maxEmptyReads {
+		t.Errorf("reader was read %d times, want at least %d", r.reads, maxEmptyReads)
+	}
+}
+
+func TestGetMediaInfoFromReader_InvalidData(t *testing.T) {
+	_, err := GetMediaInfoFromReader(bytes.NewReader([]byte("definitely not a media file")), "garbage")
+	if err == nil {
+		t.Error("Expected an error for invalid input")
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+#include <libavutil/samplefmt.h>
+*/
+import "C"
+import "strconv"
+
+// SampleFormatDescriptor describes the memory layout of an audio sample format.
+type SampleFormatDescriptor struct {
+	Format         AVSampleFormat // The described sample format.
+	Name           string         // Name of the sample format, e.g. s16 or fltp.
+	BytesPerSample int            // Number of bytes per sample of one channel.
+	Planar         bool           // Each channel is stored in a separate plane.
+}
+
+// String returns the FFmpeg name of the sample format, e.g. s16 or fltp.
+func (f AVSampleFormat) String() string {
+	if f == AV_SAMPLE_FMT_NONE {
+		return "none"
+	}
+	name := C.av_get_sample_fmt_name(C.enum_AVSampleFormat(f))
+	if name == nil {
+		return "AVSampleFormat(" + strconv.Itoa(int(f)) + ")"
+	}
+	return C.GoString(name)
+}
+
+// BytesPerSample returns the number of bytes per sample or 0 for an unknown format.
+func (f AVSampleFormat) BytesPerSample() int {
+	return int(C.av_get_bytes_per_sample(C.enum_AVSampleFormat(f)))
+}
+
+// IsPlanar reports whether each channel is stored in a separate plane.
+func (f AVSampleFormat) IsPlanar() bool {
+	return C.av_sample_fmt_is_planar(C.enum_AVSampleFormat(f)) == 1
+}
+
+// Descriptor returns the layout details of the sample format or nil if it is unknown.
+func (f AVSampleFormat) Descriptor() *SampleFormatDescriptor {
+	if C.av_get_sample_fmt_name(C.enum_AVSampleFormat(f)) == nil {
+		return nil
+	}
+	return &SampleFormatDescriptor{
+		Format:         f,
+		Name:           f.String(),
+		BytesPerSample: f.BytesPerSample(),
+		Planar:         f.IsPlanar(),
+	}
+}
@@ -0,0 +1,68 @@
+package mediafileinfo
+
+import "testing"
+
+func TestAVSampleFormat_Descriptor(t *testing.T) {
+	tests := []struct {
+		format AVSampleFormat
+		name   string
+		bytes  int
+		planar bool
+	}{
+		{AV_SAMPLE_FMT_U8, "u8", 1, false},
+		{AV_SAMPLE_FMT_S16, "s16", 2, false},
+		{AV_SAMPLE_FMT_FLTP, "fltp", 4, true},
+		{AV_SAMPLE_FMT_DBL, "dbl", 8, false},
+		{AV_SAMPLE_FMT_S64P, "s64p", 8, true},
+	}
+
+	for _, tt := range tests {
+		d := tt.format.Descriptor()
+		if d == nil {
+			t.Fatalf("%s: Expected a descriptor", tt.name)
+		}
+		if d.Name != tt.name || tt.format.String() != tt.name {
+			t.Errorf("Name = %q, want %q", d.Name, tt.name)
+		}
+		if d.BytesPerSample != tt.bytes {
+			t.Errorf("%s: BytesPerSample = %d, want %d", tt.name, d.BytesPerSample, tt.bytes)
+		}
+		if d.Planar != tt.planar {
+			t.Errorf("%s: Planar = %v, want %v", tt.name, d.Planar, tt.planar)
+		}
+	}
+
+	if AV_SAMPLE_FMT_NONE.String() != "none" || AV_SAMPLE_FMT_NONE.Descriptor() != nil {
+		t.Errorf("Expected AV_SAMPLE_FMT_NONE to be \"none\" without descriptor")
+	}
+}
+
+func TestGetMediaInfo_SampleFormat(t *testing.T) {
+	info, err := GetMediaInfo("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+
+	for _, s := range info.Streams {
+		par := s.CodecParameters
+		switch par.CodecType {
+		case AVMEDIA_TYPE_AUDIO:
+			if par.FormatText != "flt" {
+				t.Errorf("FormatText = %q, want flt", par.FormatText)
+			}
+			if par.SampleFormat == nil || par.SampleFormat.BytesPerSample != 4 || par.SampleFormat.Planar {
+				t.Errorf("SampleFormat = %+v, want 4 bytes packed", par.SampleFormat)
+			}
+			if par.PixelFormat != nil {
+				t.Errorf("Expected no PixelFormat for audio")
+			}
+		case AVMEDIA_TYPE_VIDEO:
+			if par.PixelFormat == nil || par.FormatText != par.PixelFormat.Name {
+				t.Errorf("PixelFormat = %+v, FormatText = %q", par.PixelFormat, par.FormatText)
+			}
+			if par.SampleFormat != nil {
+				t.Errorf("Expected no SampleFormat for video")
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// scanPackets calls fn for every packet of the opened input in file order.
+// Packets buffered by avformat_find_stream_info are returned first, so the scan
+// starts at the beginning of the file. The input is left open.
+func scanPackets(ctx context.Context, in *inputContext, filename string, fn func(Packet) error) error {
+	pkt := C.av_packet_alloc()
+	if pkt == nil {
+		return fmt.Errorf("could not allocate packet for %s", filename)
+	}
+	r := &PacketReader{ctx: ctx, in: in, pkt: pkt, filename: filename}
+	defer C.av_packet_free(&r.pkt)
+
+	for {
+		p, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+}
+
+// analyzePackets runs the packet based analyses requested by opts over the
+// opened input and stores their results in info. A read error ends the scan
+// early; the results then cover the packets read so far and the error is kept
+// in info.ReadError. Only a canceled ctx is returned as error.
+func analyzePackets(ctx context.Context, in *inputContext, filename string, info *AVFormatContext, opts *ProbeOptions) error {
+	gops := make(map[int]*gopBuilder)
+	measures := make(map[int]*measureBuilder)
+	for i, s := range info.Streams {
+		if opts.AnalyzeGOP && s.CodecParameters.CodecType == AVMEDIA_TYPE_VIDEO && !s.Disposition.IsAttachedPic() {
+			gops[i] = &gopBuilder{}
+		}
+		if opts.Accurate {
+			measures[i] = newMeasureBuilder()
+		}
+	}
+
+	err := scanPackets(ctx, in, filename, func(p Packet) error {
+		if b := gops[p.StreamIndex]; b != nil {
+			b.add(p)
+		}
+		if b := measures[p.StreamIndex]; b != nil {
+			b.add(p)
+		}
+		return nil
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		info.ReadError = err.Error()
+	}
+
+	for i, b := range gops {
+		info.Streams[i].GOP = b.report()
+	}
+	for i, b := range measures {
+		info.Streams[i].Measured = b.report(&info.Streams[i])
+	}
+	if opts.Accurate {
+		info.Measured = measureContainer(info)
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+#include <libavutil/dovi_meta.h>
+#include <libavutil/mastering_display_metadata.h>
+#include <libavutil/spherical.h>
+#include <libavutil/stereo3d.h>
+*/
+import "C"
+import (
+	"math"
+	"unsafe"
+)
+
+// CodedSideData holds the decoded entries of AVCodecParameters.coded_side_data.
+// Only the entries present in the stream are set.
+type CodedSideData struct {
+	DisplayMatrix     *DisplayMatrix            `json:",omitempty"` // AV_PKT_DATA_DISPLAYMATRIX
+	MasteringDisplay  *MasteringDisplayMetadata `json:",omitempty"` // AV_PKT_DATA_MASTERING_DISPLAY_METADATA
+	ContentLightLevel *ContentLightLevel        `json:",omitempty"` // AV_PKT_DATA_CONTENT_LIGHT_LEVEL
+	DOVIConfig        *DOVIDecoderConfig        `json:",omitempty"` // AV_PKT_DATA_DOVI_CONF
+	Stereo3D          *Stereo3D                 `json:",omitempty"` // AV_PKT_DATA_STEREO3D
+	Spherical         *Spherical                `json:",omitempty"` // AV_PKT_DATA_SPHERICAL
+}
+
+// DisplayMatrix describes how a video frame has to be transformed for display,
+// e.g. for videos recorded in portrait mode on a phone.
+// See: https://ffmpeg.org/doxygen/trunk/group__lavu__video__display.html
+type DisplayMatrix struct {
+	Matrix   [9]int32 // 3x3 transformation matrix, 16.16 fixed point except the last column (2.30).
+	Rotation float64  // Counterclockwise rotation in degrees [-180, 180] as av_display_rotation_get reports it.
+	HFlip    bool     // The frame is mirrored horizontally.
+}
+
+// ClockwiseRotation returns the rotation in whole degrees (0, 90, 180 or 270 for
+// common files) by which a decoded frame has to be turned clockwise for display.
+func (d *DisplayMatrix) ClockwiseRotation() int {
+	deg := int(math.Round(-d.Rotation)) % 360
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
+
+// MasteringDisplayMetadata describes the color volume of the display used to master HDR content (SMPTE ST 2086).
+// See: https://ffmpeg.org/doxygen/trunk/structAVMasteringDisplayMetadata.html
+type MasteringDisplayMetadata struct {
+	DisplayPrimaries [3][2]AVRational // CIE 1931 xy chromaticity of the red, green and blue primaries.
+	WhitePoint       [2]AVRational    // CIE 1931 xy chromaticity of the white point.
+	MinLuminance     AVRational       // Minimum luminance in cd/m².
+	MaxLuminance     AVRational       // Maximum luminance in cd/m².
+	HasPrimaries     bool             // DisplayPrimaries and WhitePoint are set.
+	HasLuminance     bool             // MinLuminance and MaxLuminance are set.
+}
+
+// ContentLightLevel holds the content light level of HDR content (CEA-861.3).
+// See: https://ffmpeg.org/doxygen/trunk/structAVContentLightMetadata.html
+type ContentLightLevel struct {
+	MaxCLL  int // Maximum content light level in cd/m².
+	MaxFALL int // Maximum frame-average light level in cd/m².
+}
+
+// DOVIDecoderConfig is the Dolby Vision decoder configuration record.
+// See: https://ffmpeg.org/doxygen/trunk/structAVDOVIDecoderConfigurationRecord.html
+type DOVIDecoderConfig struct {
+	VersionMajor            int  // Dolby Vision version, major part.
+	VersionMinor            int  // Dolby Vision version, minor part.
+	Profile                 int  // Dolby Vision profile, e.g. 5, 7 or 8.
+	Level                   int  // Dolby Vision level.
+	RPUPresent              bool // Reference processing unit is present.
+	ELPresent               bool // Enhancement layer is present.
+	BLPresent               bool // Base layer is present.
+	BLSignalCompatibilityID int  // Compatibility of the base layer, e.g. 1 for HDR10 or 4 for HLG.
+	MDCompression           int  // Metadata compression method.
+}
+
+// Stereo3D describes how the views of stereoscopic video are packed.
+// See: https://ffmpeg.org/doxygen/trunk/structAVStereo3D.html
+type Stereo3D struct {
+	Type                          string     // Packing, e.g. "side by side" or "top and bottom".
+	Inverted                      bool       // Views are stored in inverted order.
+	View                          string     // View the stream carries, e.g. "packed" or "left".
+	PrimaryEye                    string     // Primary eye, e.g. "none", "left" or "right".
+	Baseline                      uint32     // Distance between the camera centers in micrometers.
+	HorizontalDisparityAdjustment AVRational // Relative shift of the views.
+	HorizontalFieldOfView         AVRational // Horizontal field of view in degrees.
+}
+
+// Spherical describes the projection of 360° video.
+// See: https://ffmpeg.org/doxygen/trunk/structAVSphericalMapping.html
+type Spherical struct {
+	Projection  string  // Projection, e.g. "equirectangular" or "cubemap".
+	Yaw         float64 // Rotation around the up vector in degrees.
+	Pitch       float64 // Rotation around the right vector in degrees.
+	Roll        float64 // Rotation around the forward vector in degrees.
+	BoundLeft   uint32  // Equirectangular tile: distance from the left edge (0.32 fixed point).
+	BoundTop    uint32  // Equirectangular tile: distance from the top edge (0.32 fixed point).
+	BoundRight  uint32  // Equirectangular tile: distance from the right edge (0.32 fixed point).
+	BoundBottom uint32  // Equirectangular tile: distance from the bottom edge (0.32 fixed point).
+	Padding     uint32  // Cubemap: number of pixels between the faces.
+}
+
+// newCodedSideData decodes the coded side data of the codec parameters. Returns
+// nil if there is no entry of a supported type.
+func newCodedSideData(par *C.AVCodecParameters) *CodedSideData {
+	if par.nb_coded_side_data <= 0 {
+		return nil
+	}
+
+	var sd CodedSideData
+	found := false
+	for _, e := range unsafe.Slice(par.coded_side_data, int(par.nb_coded_side_data)) {
+		data := unsafe.Pointer(e.data)
+		size := uintptr(e.size)
+		switch e._type {
+		case C.AV_PKT_DATA_DISPLAYMATRIX:
+			if size < 9*4 {
+				continue
+			}
+			var m [9]int32
+			for i, v := range unsafe.Slice((*C.int32_t)(data), 9) {
+				m[i] = int32(v)
+			}
+			sd.DisplayMatrix = newDisplayMatrix(m)
+		case C.AV_PKT_DATA_MASTERING_DISPLAY_METADATA:
+			if size < unsafe.Sizeof(C.AVMasteringDisplayMetadata{}) {
+				continue
+			}
+			sd.MasteringDisplay = newMasteringDisplayMetadata((*C.AVMasteringDisplayMetadata)(data))
+		case C.AV_PKT_DATA_CONTENT_LIGHT_LEVEL:
+			if size < unsafe.Sizeof(C.AVContentLightMetadata{}) {
+				continue
+			}
+			cll := (*C.AVContentLightMetadata)(data)
+			sd.ContentLightLevel = &ContentLightLevel{MaxCLL: int(cll.MaxCLL), MaxFALL: int(cll.MaxFALL)}
+		case C.AV_PKT_DATA_DOVI_CONF:
+			if size < unsafe.Sizeof(C.AVDOVIDecoderConfigurationRecord{}) {
+				continue
+			}
+			sd.DOVIConfig = newDOVIDecoderConfig((*C.AVDOVIDecoderConfigurationRecord)(data))
+		case C.AV_PKT_DATA_STEREO3D:
+			if size < unsafe.Sizeof(C.AVStereo3D{}) {
+				continue
+			}
+			sd.Stereo3D = newStereo3D((*C.AVStereo3D)(data))
+		case C.AV_PKT_DATA_SPHERICAL:
+			if size < unsafe.Sizeof(C.AVSphericalMapping{}) {
+				continue
+			}
+			sd.Spherical = newSpherical((*C.AVSphericalMapping)(data))
+		default:
+			continue
+		}
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+	return &sd
+}
+
+// newDisplayMatrix computes rotation and flip of a display matrix the same way
+// av_display_rotation_get does; a mirrored matrix is unflipped first.
+func newDisplayMatrix(m [9]int32) *DisplayMatrix {
+	d := &DisplayMatrix{Matrix: m}
+
+	conv := func(v int32) float64 { return float64(v) / (1 << 16) }
+	a, b, c, e := conv(m[0]), conv(m[1]), conv(m[3]), conv(m[4])
+	if a*e-b*c < 0 {
+		d.HFlip = true
+		a, c = -a, -c
+	}
+
+	scale0 := math.Hypot(a, c)
+	scale1 := math.Hypot(b, e)
+	if scale0 == 0 || scale1 == 0 {
+		return d
+	}
+	d.Rotation = -math.Atan2(b/scale1, a/scale0) * 180 / math.Pi
+	if d.Rotation == 0 {
+		d.Rotation = 0 // normalize -0
+	}
+	return d
+}
+
+func newMasteringDisplayMetadata(m *C.AVMasteringDisplayMetadata) *MasteringDisplayMetadata {
+	md := &MasteringDisplayMetadata{
+		MinLuminance: newAVRational(m.min_luminance),
+		MaxLuminance: newAVRational(m.max_luminance),
+		HasPrimaries: m.has_primaries != 0,
+		HasLuminance: m.has_luminance != 0,
+	}
+	for i := range 3 {
+		for j := range 2 {
+			md.DisplayPrimaries[i][j] = newAVRational(m.display_primaries[i][j])
+		}
+	}
+	for j := range 2 {
+		md.WhitePoint[j] = newAVRational(m.white_point[j])
+	}
+	return md
+}
+
+func newDOVIDecoderConfig(r *C.AVDOVIDecoderConfigurationRecord) *DOVIDecoderConfig {
+	return &DOVIDecoderConfig{
+		VersionMajor:            int(r.dv_version_major),
+		VersionMinor:            int(r.dv_version_minor),
+		Profile:                 int(r.dv_profile),
+		Level:                   int(r.dv_level),
+		RPUPresent:              r.rpu_present_flag != 0,
+		ELPresent:               r.el_present_flag != 0,
+		BLPresent:               r.bl_present_flag != 0,
+		BLSignalCompatibilityID: int(r.dv_bl_signal_compatibility_id),
+		MDCompression:           int(r.dv_md_compression),
+	}
+}
+
+func newStereo3D(s *C.AVStereo3D) *Stereo3D {
+	return &Stereo3D{
+		Type:                          C.GoString(C.av_stereo3d_type_name(C.uint(s._type))),
+		Inverted:                      s.flags&C.AV_STEREO3D_FLAG_INVERT != 0,
+		View:                          C.GoString(C.av_stereo3d_view_name(C.uint(s.view))),
+		PrimaryEye:                    C.GoString(C.av_stereo3d_primary_eye_name(C.uint(s.primary_eye))),
+		Baseline:                      uint32(s.baseline),
+		HorizontalDisparityAdjustment: newAVRational(s.horizontal_disparity_adjustment),
+		HorizontalFieldOfView:         newAVRational(s.horizontal_field_of_view),
+	}
+}
+
+func newSpherical(s *C.AVSphericalMapping) *Spherical {
+	fixed := func(v C.int32_t) float64 { return float64(v) / (1 << 16) }
+	return &Spherical{
+		Projection:  C.GoString(C.av_spherical_projection_name(s.projection)),
+		Yaw:         fixed(s.yaw),
+		Pitch:       fixed(s.pitch),
+		Roll:        fixed(s.roll),
+		BoundLeft:   uint32(s.bound_left),
+		BoundTop:    uint32(s.bound_top),
+		BoundRight:  uint32(s.bound_right),
+		BoundBottom: uint32(s.bound_bottom),
+		Padding:     uint32(s.padding),
+	}
+}
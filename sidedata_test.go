@@ -0,0 +1,88 @@
+package mediafileinfo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewDisplayMatrix(t *testing.T) {
+	const one = 1 << 16
+	tests := []struct {
+		name      string
+		m         [9]int32
+		rotation  float64
+		clockwise int
+		hflip     bool
+	}{
+		{"identity", [9]int32{one, 0, 0, 0, one, 0, 0, 0, 1 << 30}, 0, 0, false},
+		{"90 clockwise", [9]int32{0, one, 0, -one, 0, 0, 0, 0, 1 << 30}, -90, 90, false},
+		{"90 counterclockwise", [9]int32{0, -one, 0, one, 0, 0, 0, 0, 1 << 30}, 90, 270, false},
+		{"180", [9]int32{-one, 0, 0, 0, -one, 0, 0, 0, 1 << 30}, -180, 180, false},
+		{"mirrored", [9]int32{-one, 0, 0, 0, one, 0, 0, 0, 1 << 30}, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		d := newDisplayMatrix(tt.m)
+		if math.Abs(d.Rotation-tt.rotation) > 1e-9 {
+			t.Errorf("%s: Rotation = %v, want %v", tt.name, d.Rotation, tt.rotation)
+		}
+		if got := d.ClockwiseRotation(); got != tt.clockwise {
+			t.Errorf("%s: ClockwiseRotation() = %d, want %d", tt.name, got, tt.clockwise)
+		}
+		if d.HFlip != tt.hflip {
+			t.Errorf("%s: HFlip = %v, want %v", tt.name, d.HFlip, tt.hflip)
+		}
+	}
+}
+
+func TestNewDisplayMatrix_Singular(t *testing.T) {
+	d := newDisplayMatrix([9]int32{})
+	if d.Rotation != 0 || d.HFlip {
+		t.Errorf("singular matrix: got Rotation %v, HFlip %v, want 0, false", d.Rotation, d.HFlip)
+	}
+}
+
+func TestGetMediaInfo_SideData(t *testing.T) {
+	// Matroska VP9 track with HDR10 Colour elements and a projection rolled by 90°
+	info, err := GetMediaInfo("testdata/hdr_rotated.mkv")
+	if err != nil {
+		t.Fatalf("GetMediaInfo returned error: %v", err)
+	}
+	if len(info.Streams) != 1 {
+		t.Fatalf("got %d streams, want 1", len(info.Streams))
+	}
+	sd := info.Streams[0].SideData
+	if sd == nil {
+		t.Fatal("Expected coded side data")
+	}
+
+	if sd.ContentLightLevel == nil || sd.ContentLightLevel.MaxCLL != 1000 || sd.ContentLightLevel.MaxFALL != 400 {
+		t.Errorf("ContentLightLevel = %+v, want MaxCLL 1000, MaxFALL 400", sd.ContentLightLevel)
+	}
+
+	md := sd.MasteringDisplay
+	if md == nil || !md.HasPrimaries || !md.HasLuminance {
+		t.Fatalf("MasteringDisplay = %+v, want primaries and luminance", md)
+	}
+	near := func(r AVRational, want float64) bool { return math.Abs(r.Float64()-want) < 1e-4 }
+	if !near(md.DisplayPrimaries[0][0], 0.708) || !near(md.DisplayPrimaries[1][1], 0.797) || !near(md.DisplayPrimaries[2][1], 0.046) {
+		t.Errorf("DisplayPrimaries = %v, want BT.2020 primaries", md.DisplayPrimaries)
+	}
+	if !near(md.WhitePoint[0], 0.3127) || !near(md.WhitePoint[1], 0.3290) {
+		t.Errorf("WhitePoint = %v, want D65", md.WhitePoint)
+	}
+	if !near(md.MaxLuminance, 1000) || !near(md.MinLuminance, 0.0001) {
+		t.Errorf("Min/MaxLuminance = %v/%v, want 0.0001/1000", md.MinLuminance, md.MaxLuminance)
+	}
+
+	dm := sd.DisplayMatrix
+	if dm == nil {
+		t.Fatal("Expected a DisplayMatrix")
+	}
+	if math.Abs(math.Abs(dm.Rotation)-90) > 1e-6 || dm.HFlip {
+		t.Errorf("Rotation/HFlip = %v/%v, want ±90 without flip", dm.Rotation, dm.HFlip)
+	}
+	if cw := dm.ClockwiseRotation(); cw != 90 && cw != 270 {
+		t.Errorf("ClockwiseRotation() = %d, want 90 or 270", cw)
+	}
+}
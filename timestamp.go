@@ -0,0 +1,31 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+*/
+import "C"
+import "time"
+
+// avTimeBaseQ is the time base of the timestamps of a format context (AV_TIME_BASE_Q, microseconds).
+var avTimeBaseQ = C.AVRational{num: 1, den: C.AV_TIME_BASE}
+
+// ticksToDuration converts a timestamp in units of the time base tb to a
+// time.Duration. Returns 0 for AV_NOPTS_VALUE or an invalid time base.
+func ticksToDuration(ticks C.int64_t, tb C.AVRational) time.Duration {
+	if int64(ticks) == AV_NOPTS_VALUE || tb.num <= 0 || tb.den <= 0 {
+		return 0
+	}
+	us := C.av_rescale_q(ticks, tb, C.AVRational{num: 1, den: 1000000})
+	return time.Duration(us) * time.Microsecond
+}
+
+// ticksToText formats a timestamp in units of the time base tb like
+// FormatDuration does, or returns "N/A" for AV_NOPTS_VALUE.
+func ticksToText(ticks C.int64_t, tb C.AVRational) string {
+	if int64(ticks) == AV_NOPTS_VALUE || tb.num <= 0 || tb.den <= 0 {
+		return "N/A"
+	}
+	return FormatDuration(ticksToDuration(ticks, tb))
+}
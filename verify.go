@@ -0,0 +1,388 @@
+// Copyright 2025 archeopternix. All rights reserved. MIT license.
+
+package mediafileinfo
+
+/*
+#include "mediainfowrapper.h"
+#include <libavcodec/avcodec.h>
+*/
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// maxIntegrityProblems limits the problems recorded per stream; the counters of
+// a StreamIntegrity keep counting beyond it.
+const maxIntegrityProblems = 100
+
+// maxIntegrityMessages limits the FFmpeg messages kept in an IntegrityReport.
+const maxIntegrityMessages = 1000
+
+// Kinds of an IntegrityProblem.
+const (
+	ProblemDecodeError      = "decode_error"      // The decoder rejected a packet or failed to output a frame.
+	ProblemCorruptPacket    = "corrupt_packet"    // The demuxer flagged the packet as corrupt.
+	ProblemCorruptFrame     = "corrupt_frame"     // The decoder output a frame with errors, e.g. with concealment.
+	ProblemMissingReference = "missing_reference" // A frame references a frame which is missing.
+	ProblemTimestamp        = "timestamp"         // Decreasing DTS, repeated DTS of audio or video, or PTS before DTS.
+)
+
+// IntegrityReport is the result of VerifyDecode.
+type IntegrityReport struct {
+	Filename             string            // Name of the verified file.
+	OK                   bool              // No stream had any problem and the file was read to its end.
+	ReadError            string            `json:",omitempty"` // Error which stopped reading before the end of the file.
+	Streams              []StreamIntegrity // Result of every stream.
+	UnknownStreamPackets int64             `json:",omitempty"` // Packets of streams which appeared after the header and were not checked.
+	Messages             []string          `json:",omitempty"` // The first warnings and errors FFmpeg logged while decoding.
+	DroppedMessages      int               `json:",omitempty"` // Messages left out of Messages beyond its limit of 1000.
+}
+
+// StreamIntegrity is the decode result of one stream.
+type StreamIntegrity struct {
+	StreamIndex       int                // Index of the stream.
+	CodecType         AVMediaType        // Type of the stream.
+	CodecTypeText     string             // Type of the stream as text
+	Decoder           string             `json:",omitempty"` // Name of the decoder used.
+	Skipped           string             `json:",omitempty"` // Reason why the stream was not decoded, e.g. no decoder.
+	Packets           int64              // Number of packets read.
+	Frames            int64              // Number of frames, or subtitles, decoded.
+	DecodeErrors      int                // Packets or frames the decoder failed on.
+	CorruptPackets    int                // Packets flagged corrupt by the demuxer.
+	CorruptFrames     int                // Frames output with decode errors.
+	MissingReferences int                // Frames with missing reference frames.
+	TimestampErrors   int                // Packets with non-monotonic DTS or PTS before DTS, see ProblemTimestamp.
+	Problems          []IntegrityProblem `json:",omitempty"` // The first problems with their position.
+}
+
+// IntegrityProblem locates a single problem.
+type IntegrityProblem struct {
+	Kind    string        // One of the Problem* constants.
+	PTS     int64         // Timestamp of the packet in stream time_base units, AV_NOPTS_VALUE if unknown.
+	Time    time.Duration // Timestamp of the packet as time.
+	Message string        // FFmpeg's error text or log message.
+}
+
+// OK reports whether the stream was decoded without any problem.
+func (s *StreamIntegrity) OK() bool {
+	return s.DecodeErrors == 0 && s.CorruptPackets == 0 && s.CorruptFrames == 0 &&
+		s.MissingReferences == 0 && s.TimestampErrors == 0
+}
+
+// VerifyDecode decodes every stream of filename completely, like
+// "ffmpeg -i file -f null -", to find files which are corrupt somewhere after
+// their header. Streams without decoder, e.g. attachments, are skipped. The
+// decoders run single-threaded so that FFmpeg's messages can be collected.
+// An error is returned if the file cannot be opened or ctx is canceled; problems
+// found while reading or decoding are reported in the IntegrityReport.
+func VerifyDecode(ctx context.Context, filename string) (*IntegrityReport, error) {
+	collector := startLogCollector()
+	report, err := verifyDecode(ctx, filename, collector)
+	messages := collector.stop()
+	if err != nil {
+		return nil, err
+	}
+	report.addMessages(messages)
+	return report, nil
+}
+
+func verifyDecode(ctx context.Context, filename string, collector *logCollector) (*IntegrityReport, error) {
+	in, err := openInput(ctx, filename, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer in.close()
+
+	num := int(C.Get_stream_count(in.fc))
+	report := &IntegrityReport{Filename: filename, OK: true, Streams: make([]StreamIntegrity, num)}
+	decoders := make([]*streamDecoder, num)
+	defer func() {
+		for _, d := range decoders {
+			d.free()
+		}
+	}()
+	for i := range num {
+		s := C.Get_stream_by_index(in.fc, C.int(i))
+		ctp := AVMediaType(int(s.codecpar.codec_type))
+		si := &report.Streams[i]
+		si.StreamIndex, si.CodecType, si.CodecTypeText = i, ctp, ctp.String()
+		decoders[i], si.Decoder, si.Skipped = newStreamDecoder(s, si)
+	}
+
+	pkt := C.av_packet_alloc()
+	if pkt == nil {
+		return nil, fmt.Errorf("could not allocate packet for %s", filename)
+	}
+	r := &PacketReader{ctx: ctx, in: in, pkt: pkt, filename: filename}
+	defer C.av_packet_free(&r.pkt)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		report.addMessages(collector.take())
+		err := r.read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			report.OK = false
+			report.ReadError = err.Error()
+			break
+		}
+
+		idx := int(r.pkt.stream_index)
+		if idx < 0 || idx >= num {
+			report.UnknownStreamPackets++
+			continue
+		}
+		p := r.packet()
+		si := &report.Streams[idx]
+		si.Packets++
+		if p.Corrupt {
+			si.CorruptPackets++
+			si.addProblem(ProblemCorruptPacket, p.PTS, p.PTSTime, "")
+		}
+		if d := decoders[idx]; d != nil {
+			d.checkTimestamps(p, si)
+			start := collector.count()
+			d.decode(r.pkt, p, si, func() []string { return collector.since(start) })
+		}
+	}
+
+	for i, d := range decoders {
+		if d != nil {
+			d.decode(nil, Packet{PTS: AV_NOPTS_VALUE}, &report.Streams[i], func() []string { return nil })
+		}
+	}
+	report.addMessages(collector.take())
+
+	for i := range report.Streams {
+		if !report.Streams[i].OK() {
+			report.OK = false
+		}
+	}
+	return report, nil
+}
+
+// streamDecoder decodes the packets of one stream.
+type streamDecoder struct {
+	dec     *C.AVCodecContext
+	frame   *C.AVFrame
+	tb      C.AVRational
+	typ     AVMediaType
+	lastDTS int64
+}
+
+// newStreamDecoder opens a single-threaded decoder for the stream. It returns
+// nil and the reason in skipped if the stream cannot be decoded.
+func newStreamDecoder(s *C.AVStream, si *StreamIntegrity) (d *streamDecoder, name, skipped string) {
+	codec := C.avcodec_find_decoder(s.codecpar.codec_id)
+	if codec == nil {
+		return nil, "", "no decoder"
+	}
+	name = C.GoString(codec.name)
+
+	d = &streamDecoder{tb: s.time_base, typ: AVMediaType(int(s.codecpar.codec_type)), lastDTS: AV_NOPTS_VALUE}
+	d.dec = C.avcodec_alloc_context3(codec)
+	d.frame = C.av_frame_alloc()
+	if d.dec == nil || d.frame == nil {
+		d.free()
+		return nil, name, "could not allocate decoder"
+	}
+	if ret := C.avcodec_parameters_to_context(d.dec, s.codecpar); ret < 0 {
+		d.free()
+		return nil, name, avErrorText(ret)
+	}
+	d.dec.thread_count = 1
+	d.dec.pkt_timebase = s.time_base
+	if ret := C.avcodec_open2(d.dec, codec, nil); ret < 0 {
+		d.free()
+		si.DecodeErrors++
+		si.addProblem(ProblemDecodeError, AV_NOPTS_VALUE, 0, "could not open decoder: "+avErrorText(ret))
+		return nil, name, "could not open decoder"
+	}
+	return d, name, ""
+}
+
+// free releases the decoder; d may be nil.
+func (d *streamDecoder) free() {
+	if d == nil {
+		return
+	}
+	C.av_frame_free(&d.frame)
+	C.avcodec_free_context(&d.dec)
+}
+
+// checkTimestamps counts packets whose DTS does not increase or whose PTS lies
+// before the DTS. Subtitle and data packets may repeat the DTS, e.g. for cues
+// shown at the same time, so only a decreasing DTS counts for them.
+func (d *streamDecoder) checkTimestamps(p Packet, si *StreamIntegrity) {
+	repeatOK := d.typ != AVMEDIA_TYPE_AUDIO && d.typ != AVMEDIA_TYPE_VIDEO
+	switch {
+	case p.DTS != AV_NOPTS_VALUE && d.lastDTS != AV_NOPTS_VALUE &&
+		(p.DTS < d.lastDTS || p.DTS == d.lastDTS && !repeatOK):
+		si.TimestampErrors++
+		si.addProblem(ProblemTimestamp, p.PTS, p.PTSTime, fmt.Sprintf("non-monotonic DTS %d after %d", p.DTS, d.lastDTS))
+	case p.PTS != AV_NOPTS_VALUE && p.DTS != AV_NOPTS_VALUE && p.PTS < p.DTS:
+		si.TimestampErrors++
+		si.addProblem(ProblemTimestamp, p.PTS, p.PTSTime, fmt.Sprintf("PTS %d before DTS %d", p.PTS, p.DTS))
+	}
+	if p.DTS != AV_NOPTS_VALUE {
+		d.lastDTS = p.DTS
+	}
+}
+
+// decode sends pkt to the decoder, or flushes it if pkt is nil, and checks all
+// frames it outputs. messages returns what FFmpeg logged meanwhile.
+func (d *streamDecoder) decode(pkt *C.AVPacket, p Packet, si *StreamIntegrity, messages func() []string) {
+	message := func(ret C.int) string {
+		if logged := messages(); len(logged) > 0 {
+			return strings.Join(logged, "; ")
+		}
+		if ret < 0 {
+			return avErrorText(ret)
+		}
+		return ""
+	}
+
+	if d.typ == AVMEDIA_TYPE_SUBTITLE {
+		d.decodeSubtitle(pkt, p, si, message)
+		return
+	}
+
+	decodeCycle(
+		func() int { return int(C.avcodec_send_packet(d.dec, pkt)) },
+		func() int { return int(C.avcodec_receive_frame(d.dec, d.frame)) },
+		func() {
+			d.checkFrame(si, message)
+			C.av_frame_unref(d.frame)
+		},
+		func(ret int) {
+			si.DecodeErrors++
+			si.addProblem(ProblemDecodeError, p.PTS, p.PTSTime, message(C.int(ret)))
+		})
+}
+
+// AVERROR codes of the send/receive API as Go values.
+var (
+	averrorEAGAIN = int(C.AVERROR_EAGAIN)
+	averrorEOF    = int(C.AVERROR_EOF)
+)
+
+// decodeCycle sends one packet with send and drains the decoder with receive,
+// both returning AVERROR codes. frame is called for every frame received, fail
+// for every decode error. A full decoder (EAGAIN) gets the packet again after
+// draining, unless draining made no progress: then the packet is given up with
+// a single error instead of resending it forever.
+func decodeCycle(send, receive func() int, frame func(), fail func(ret int)) {
+	for {
+		sent := send()
+		if sent < 0 && sent != averrorEAGAIN && sent != averrorEOF {
+			fail(sent)
+			return
+		}
+
+		received, stopped := false, false
+		for {
+			ret := receive()
+			if ret == averrorEAGAIN {
+				break
+			}
+			if ret == averrorEOF {
+				stopped = true
+				break
+			}
+			if ret < 0 {
+				fail(ret)
+				return
+			}
+			frame()
+			received = true
+		}
+
+		if sent != averrorEAGAIN {
+			return
+		}
+		if stopped || !received {
+			fail(sent)
+			return
+		}
+	}
+}
+
+// decodeSubtitle decodes pkt with avcodec_decode_subtitle2, which subtitle
+// decoders use instead of send_packet/receive_frame. A nil pkt flushes decoders
+// with delay.
+func (d *streamDecoder) decodeSubtitle(pkt *C.AVPacket, p Packet, si *StreamIntegrity, message func(C.int) string) {
+	flush := pkt == nil
+	if flush {
+		if d.dec.codec.capabilities&C.AV_CODEC_CAP_DELAY == 0 {
+			return
+		}
+		// an empty packet drains the decoder
+		pkt = C.av_packet_alloc()
+		if pkt == nil {
+			return
+		}
+		defer C.av_packet_free(&pkt)
+	}
+
+	for {
+		var sub C.AVSubtitle
+		var got C.int
+		ret := C.avcodec_decode_subtitle2(d.dec, &sub, &got, pkt)
+		if ret < 0 {
+			si.DecodeErrors++
+			si.addProblem(ProblemDecodeError, p.PTS, p.PTSTime, message(ret))
+			return
+		}
+		if got == 0 {
+			return
+		}
+		si.Frames++
+		C.avsubtitle_free(&sub)
+		if !flush {
+			return
+		}
+	}
+}
+
+// checkFrame counts a decoded frame and the errors the decoder flagged on it.
+func (d *streamDecoder) checkFrame(si *StreamIntegrity, message func(C.int) string) {
+	si.Frames++
+	f := d.frame
+	pts := int64(f.best_effort_timestamp)
+	t := ticksToDuration(f.best_effort_timestamp, d.tb)
+	switch flags := f.decode_error_flags; {
+	case flags&C.FF_DECODE_ERROR_MISSING_REFERENCE != 0:
+		si.MissingReferences++
+		si.addProblem(ProblemMissingReference, pts, t, message(0))
+	case flags != 0 || f.flags&C.AV_FRAME_FLAG_CORRUPT != 0:
+		si.CorruptFrames++
+		si.addProblem(ProblemCorruptFrame, pts, t, message(0))
+	}
+}
+
+// addMessages appends FFmpeg messages up to maxIntegrityMessages and counts the rest.
+func (r *IntegrityReport) addMessages(messages []string) {
+	n := min(len(messages), max(maxIntegrityMessages-len(r.Messages), 0))
+	r.Messages = append(r.Messages, messages[:n]...)
+	r.DroppedMessages += len(messages) - n
+}
+
+// addProblem records a problem unless maxIntegrityProblems are recorded already.
+func (si *StreamIntegrity) addProblem(kind string, pts int64, t time.Duration, msg string) {
+	if len(si.Problems) >= maxIntegrityProblems {
+		return
+	}
+	si.Problems = append(si.Problems, IntegrityProblem{Kind: kind, PTS: pts, Time: t, Message: msg})
+}
@@ -0,0 +1,166 @@
+package mediafileinfo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyDecode(t *testing.T) {
+	report, err := VerifyDecode(context.Background(), "testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("VerifyDecode returned error: %v", err)
+	}
+	if !report.OK {
+		t.Errorf("Expected sample.avi to decode without problems: %+v", report)
+	}
+	if len(report.Streams) != 2 {
+		t.Fatalf("got %d streams, want 2", len(report.Streams))
+	}
+
+	video := report.Streams[0]
+	if video.Decoder != "ffvhuff" {
+		t.Errorf("video: Decoder = %q, want ffvhuff", video.Decoder)
+	}
+	if video.Packets == 0 || video.Frames != video.Packets {
+		t.Errorf("video: Frames = %d, want one per packet (%d)", video.Frames, video.Packets)
+	}
+	if audio := report.Streams[1]; audio.Frames == 0 {
+		t.Errorf("audio: Expected decoded frames")
+	}
+}
+
+func TestVerifyDecode_Subtitles(t *testing.T) {
+	// S_TEXT/UTF8 track with four cues, two of them at the same time
+	report, err := VerifyDecode(context.Background(), "testdata/subtitles.mkv")
+	if err != nil {
+		t.Fatalf("VerifyDecode returned error: %v", err)
+	}
+	if !report.OK {
+		t.Errorf("Expected subtitles.mkv to decode without problems: %+v", report)
+	}
+	if len(report.Streams) != 1 {
+		t.Fatalf("got %d streams, want 1", len(report.Streams))
+	}
+
+	sub := report.Streams[0]
+	if sub.CodecType != AVMEDIA_TYPE_SUBTITLE || sub.Skipped != "" || sub.Decoder != "subrip" {
+		t.Errorf("CodecType/Skipped/Decoder = %v/%q/%q, want a decoded subrip stream", sub.CodecType, sub.Skipped, sub.Decoder)
+	}
+	if sub.Packets != 4 || sub.Frames != 4 {
+		t.Errorf("Packets/Frames = %d/%d, want 4/4", sub.Packets, sub.Frames)
+	}
+	if sub.TimestampErrors != 0 || sub.DecodeErrors != 0 {
+		t.Errorf("TimestampErrors/DecodeErrors = %d/%d, want 0/0", sub.TimestampErrors, sub.DecodeErrors)
+	}
+}
+
+func TestStreamDecoder_CheckTimestamps(t *testing.T) {
+	tests := []struct {
+		typ  AVMediaType
+		dts  []int64
+		want int
+	}{
+		{AVMEDIA_TYPE_VIDEO, []int64{0, 1, 1, 2}, 1},
+		{AVMEDIA_TYPE_AUDIO, []int64{0, 2, 1}, 1},
+		{AVMEDIA_TYPE_SUBTITLE, []int64{0, 1, 1, 2}, 0},
+		{AVMEDIA_TYPE_SUBTITLE, []int64{0, 2, 1}, 1},
+		{AVMEDIA_TYPE_DATA, []int64{5, 5, 5}, 0},
+	}
+
+	for _, tt := range tests {
+		d := &streamDecoder{typ: tt.typ, lastDTS: AV_NOPTS_VALUE}
+		si := &StreamIntegrity{}
+		for _, dts := range tt.dts {
+			d.checkTimestamps(Packet{PTS: dts, DTS: dts}, si)
+		}
+		if si.TimestampErrors != tt.want {
+			t.Errorf("%v %v: TimestampErrors = %d, want %d", tt.typ, tt.dts, si.TimestampErrors, tt.want)
+		}
+	}
+}
+
+func TestDecodeCycle(t *testing.T) {
+	const errInvalid = -22 // AVERROR(EINVAL)
+	tests := []struct {
+		name          string
+		sends         []int // results of send, the last one repeats
+		receives      []int // results of receive, the last one repeats
+		frames, fails int
+	}{
+		{"accepted", []int{0}, []int{0, averrorEAGAIN}, 1, 0},
+		{"full decoder drained", []int{averrorEAGAIN, 0}, []int{0, 0, averrorEAGAIN}, 2, 0},
+		{"rejected packet", []int{errInvalid}, []int{averrorEAGAIN}, 0, 1},
+		// the decoder neither accepts the packet nor outputs a frame: no endless resend
+		{"stuck decoder", []int{averrorEAGAIN}, []int{averrorEAGAIN}, 0, 1},
+		{"drain error while full", []int{averrorEAGAIN}, []int{errInvalid}, 0, 1},
+		{"drain EOF while full", []int{averrorEAGAIN}, []int{0, averrorEOF}, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := func(results []int, n *int) int {
+				r := results[min(*n, len(results)-1)]
+				*n++
+				return r
+			}
+			var sent, received, frames, fails int
+			decodeCycle(
+				func() int {
+					if sent > 1000 {
+						t.Fatal("packet resent endlessly")
+					}
+					return next(tt.sends, &sent)
+				},
+				func() int { return next(tt.receives, &received) },
+				func() { frames++ },
+				func(int) { fails++ })
+			if frames != tt.frames || fails != tt.fails {
+				t.Errorf("frames/fails = %d/%d, want %d/%d", frames, fails, tt.frames, tt.fails)
+			}
+		})
+	}
+}
+
+func TestIntegrityReport_AddMessages(t *testing.T) {
+	r := &IntegrityReport{}
+	r.addMessages(make([]string, maxIntegrityMessages-1))
+	r.addMessages([]string{"a", "b", "c"})
+	r.addMessages([]string{"d"})
+	if len(r.Messages) != maxIntegrityMessages || r.Messages[maxIntegrityMessages-1] != "a" {
+		t.Errorf("got %d messages, want %d ending with the first of the second batch", len(r.Messages), maxIntegrityMessages)
+	}
+	if r.DroppedMessages != 3 {
+		t.Errorf("DroppedMessages = %d, want 3", r.DroppedMessages)
+	}
+}
+
+func TestVerifyDecode_Truncated(t *testing.T) {
+	data, err := os.ReadFile("testdata/sample.avi")
+	if err != nil {
+		t.Fatalf("could not read test file: %v", err)
+	}
+	// cut the file in the middle of the packet data, the last packet is incomplete
+	name := filepath.Join(t.TempDir(), "truncated.avi")
+	if err := os.WriteFile(name, data[:len(data)*2/3], 0o644); err != nil {
+		t.Fatalf("could not write test file: %v", err)
+	}
+
+	report, err := VerifyDecode(context.Background(), name)
+	if err != nil {
+		t.Fatalf("VerifyDecode returned error: %v", err)
+	}
+	if report.OK {
+		t.Errorf("Expected problems in a truncated file: %+v", report)
+	}
+}
+
+func TestVerifyDecode_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := VerifyDecode(ctx, "testdata/sample.avi"); !errors.Is(err, context.Canceled) {
+		t.Errorf("VerifyDecode with canceled context = %v, want context.Canceled", err)
+	}
+}